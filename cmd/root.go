@@ -24,21 +24,28 @@ package cmd
 
 import (
 	"errors"
+	"fmt"
+	"io"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
 	"github.com/checkov-docs/checkov-docs/internal/cli"
+	"github.com/checkov-docs/checkov-docs/internal/config"
+	"github.com/checkov-docs/checkov-docs/internal/links"
 	"github.com/checkov-docs/checkov-docs/internal/logger"
 	"github.com/checkov-docs/checkov-docs/internal/version"
 )
 
 var (
-	cmdLogger  *logger.Logger
-	cfgFile    string
-	inputFile  string
-	outputFile string
+	cmdLogger   *logger.Logger
+	cfgFile     string
+	inputFiles  []string
+	inputFormat string
+	outputFile  string
+	resultType  string
 )
 
 // rootCmd represents the base command when called without any subcommands.
@@ -60,19 +67,84 @@ var rootCmd = &cobra.Command{
 		return nil
 	},
 	RunE: func(cmd *cobra.Command, args []string) error {
-		in := viper.GetString("input-file")
+		in := viper.GetStringSlice("input-file")
+		inFormat := viper.GetString("input-format")
 		out := viper.GetString("output-file")
+		rt := viper.GetString("result-type")
+		section := viper.GetString("section")
+		outputMode := viper.GetString("output-mode")
+		backup := viper.GetBool("backup")
+		followSymlinks := viper.GetBool("follow-symlinks")
+		filters := viper.GetStringSlice("filter")
+		sortKeys := viper.GetStringSlice("sort")
+		groupBy := viper.GetString("group-by")
+		columns, err := config.ParseColumns(viper.Get("columns"))
+		if err != nil {
+			return err
+		}
+		commentSyntax, err := config.ParseCommentSyntax(viper.Get("comment-syntax"))
+		if err != nil {
+			return err
+		}
+		markers := config.Markers{
+			Begin:  viper.GetString("begin-marker"),
+			End:    viper.GetString("end-marker"),
+			Syntax: commentSyntax,
+		}
+		migrateMarkers := viper.GetBool("migrate-markers")
+		format := viper.GetString("format")
+		standalone := viper.GetBool("standalone")
+		codeReasons := viper.GetBool("code-reasons")
+		linkOpts := links.Options{
+			Guidelines:      viper.GetBool("link-guidelines"),
+			RepoURL:         viper.GetString("repo-url"),
+			Ref:             viper.GetString("ref"),
+			Forge:           viper.GetString("forge"),
+			FileURLTemplate: viper.GetString("file-url-template"),
+		}
+		templateFile := viper.GetString("template")
+		summary := viper.GetBool("summary")
 		dryrun := viper.GetBool("dry-run")
-		cmdLogger.Info("run", "cmd", cmd.Aliases, "args", args, "input-file", in, "output-file", out, "dry-run", dryrun)
-		if in != "" {
-			err := cli.Generate(in, out, dryrun, cmdLogger)
-			if err != nil {
-				return err
-			}
-		} else {
+		check := viper.GetBool("check")
+		cmdLogger.Info("run", "cmd", cmd.Aliases, "args", args, "input-file", in, "input-format", inFormat, "output-file", out, "output-mode", outputMode, "backup", backup, "follow-symlinks", followSymlinks, "result-type", rt, "section", section, "markers", markers, "migrate-markers", migrateMarkers, "filter", filters, "sort", sortKeys, "group-by", groupBy, "columns", columns, "format", format, "standalone", standalone, "code-reasons", codeReasons, "links", linkOpts, "template", templateFile, "summary", summary, "dry-run", dryrun, "check", check)
+		if len(in) == 0 && cli.IsPiped(os.Stdin) {
+			// read checkov results piped to stdin, e.g. `checkov -d . -o json | checkov-docs`
+			in = []string{config.StdinInput}
+		}
+		if len(in) == 0 {
 			return errors.New("input file is required")
 		}
-		return nil
+
+		inputs, closeInputs, err := openInputs(in)
+		if err != nil {
+			return err
+		}
+		defer closeInputs()
+
+		opts := &cli.Options{
+			InputFormat:    inFormat,
+			OutputFile:     out,
+			OutputMode:     outputMode,
+			Backup:         backup,
+			FollowSymlinks: followSymlinks,
+			ResultType:     rt,
+			Section:        section,
+			Markers:        markers,
+			MigrateMarkers: migrateMarkers,
+			Columns:        columns,
+			Filters:        filters,
+			Sort:           sortKeys,
+			GroupBy:        groupBy,
+			Format:         format,
+			Standalone:     standalone,
+			Links:          linkOpts,
+			CodeReasons:    codeReasons,
+			TemplateFile:   templateFile,
+			Summary:        summary,
+			DryRun:         dryrun,
+			Check:          check,
+		}
+		return cli.Generate(inputs, opts, cmdLogger)
 	},
 }
 
@@ -90,14 +162,62 @@ func Execute() error {
 func init() {
 	rootCmd.SetVersionTemplate("{{.Version}}")
 	rootCmd.PersistentFlags().StringVarP(&cfgFile, "config", "c", ".checkov-docs.yaml", "config file")
-	rootCmd.PersistentFlags().StringVarP(&inputFile, "input-file", "i", "", "input file, valid formats: json")
-	rootCmd.PersistentFlags().StringVarP(&outputFile, "output-file", "o", "README.md", "output file")
+	rootCmd.PersistentFlags().StringArrayVarP(&inputFiles, "input-file", "i", nil, "input file or glob pattern, can be repeated, use - to read from stdin, valid formats: json, sarif, junitxml")
+	rootCmd.PersistentFlags().StringVar(&inputFormat, "input-format", config.InputFormatAuto, fmt.Sprintf("format of the input file, valid values: %s", strings.Join(config.InputFormats, ", ")))
+	rootCmd.PersistentFlags().StringVarP(&outputFile, "output-file", "o", "", fmt.Sprintf("output file, use - to write to stdout, %s by default or stdout for data formats and standalone pages", config.DefaultOutputFile))
+	rootCmd.PersistentFlags().String("output-mode", config.OutputModeAppend, fmt.Sprintf("how the section is written to the output file, valid values: %s", strings.Join(config.OutputModes, ", ")))
+	rootCmd.PersistentFlags().Bool("backup", false, "copy the output file to a .bak file before modifying it")
+	rootCmd.PersistentFlags().Bool("follow-symlinks", false, "write the target of the output file if it's a symbolic link instead of failing")
+	rootCmd.PersistentFlags().StringVarP(&resultType, "result-type", "t", config.ResultTypeSkipped, fmt.Sprintf("type of checkov results to document, valid values: %s", strings.Join(config.ResultTypes, ", ")))
+	rootCmd.PersistentFlags().String("section", "", "name of the section of the output file to write, e.g. terraform for <!-- BEGIN_CHECKOV_DOCS name=terraform -->")
+	rootCmd.PersistentFlags().String("begin-marker", "", "name of the opening tag of sections, BEGIN_CHECKOV_DOCS by default")
+	rootCmd.PersistentFlags().String("end-marker", "", "name of the closing tag of sections, END_CHECKOV_DOCS by default")
+	rootCmd.PersistentFlags().String("comment-syntax", "", fmt.Sprintf("comment syntax of the tags, the syntax of the output format by default, valid values: %s", strings.Join(config.CommentSyntaxes, ", ")))
+	rootCmd.PersistentFlags().Bool("migrate-markers", false, "rewrite the default tags of the section with the configured tags")
+	rootCmd.PersistentFlags().StringArray("filter", nil, "select checks with field=pattern or exclude them with field!=pattern, can be repeated")
+	rootCmd.PersistentFlags().StringSlice("sort", nil, "sort checks by fields with field[:asc|:desc], e.g. severity:desc,file, later fields break ties")
+	rootCmd.PersistentFlags().String("group-by", "", "render a sub-heading and a table per value of a field, valid values: file, check_id, framework, resource_type")
+	rootCmd.PersistentFlags().Bool("code-reasons", false, "render reasons of skipped checks as inline code")
+	rootCmd.PersistentFlags().StringP("format", "f", config.FormatMarkdown, fmt.Sprintf("output format, valid values: %s", strings.Join(config.Formats, ", ")))
+	rootCmd.PersistentFlags().Bool("standalone", false, "write a standalone html page with embedded CSS instead of injecting a section between tags")
+	rootCmd.PersistentFlags().Bool("link-guidelines", false, "link check IDs to their guideline")
+	rootCmd.PersistentFlags().String("repo-url", "", "URL of the repository to link file paths to their line range, e.g. https://github.com/org/repo")
+	rootCmd.PersistentFlags().String("ref", links.DefaultRef, "git branch, tag or commit file paths are linked to")
+	rootCmd.PersistentFlags().String("forge", links.ForgeGitHub, fmt.Sprintf("forge hosting the repository, valid values: %s", strings.Join(links.Forges(), ", ")))
+	rootCmd.PersistentFlags().String("file-url-template", "", "Go template of file URLs, overrides the forge, e.g. {{.RepoURL}}/blob/{{.Ref}}/{{.Path}}#L{{.Start}}-L{{.End}}")
+	rootCmd.PersistentFlags().String("template-file", "", "Go template file rendering the generated section")
+	rootCmd.PersistentFlags().Bool("summary", false, "render checkov summary above the table")
 	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "show debug output")
 	rootCmd.PersistentFlags().Bool("dry-run", false, "only print generated output")
+	rootCmd.PersistentFlags().Bool("check", false, fmt.Sprintf("check that the output file is up to date without writing it, print a diff and exit with %d otherwise", cli.ExitCodeOutdated))
 	cobra.CheckErr(viper.BindPFlag("input-file", rootCmd.PersistentFlags().Lookup("input-file")))
+	cobra.CheckErr(viper.BindPFlag("input-format", rootCmd.PersistentFlags().Lookup("input-format")))
 	cobra.CheckErr(viper.BindPFlag("output-file", rootCmd.PersistentFlags().Lookup("output-file")))
+	cobra.CheckErr(viper.BindPFlag("output-mode", rootCmd.PersistentFlags().Lookup("output-mode")))
+	cobra.CheckErr(viper.BindPFlag("backup", rootCmd.PersistentFlags().Lookup("backup")))
+	cobra.CheckErr(viper.BindPFlag("follow-symlinks", rootCmd.PersistentFlags().Lookup("follow-symlinks")))
+	cobra.CheckErr(viper.BindPFlag("result-type", rootCmd.PersistentFlags().Lookup("result-type")))
+	cobra.CheckErr(viper.BindPFlag("section", rootCmd.PersistentFlags().Lookup("section")))
+	cobra.CheckErr(viper.BindPFlag("begin-marker", rootCmd.PersistentFlags().Lookup("begin-marker")))
+	cobra.CheckErr(viper.BindPFlag("end-marker", rootCmd.PersistentFlags().Lookup("end-marker")))
+	cobra.CheckErr(viper.BindPFlag("comment-syntax", rootCmd.PersistentFlags().Lookup("comment-syntax")))
+	cobra.CheckErr(viper.BindPFlag("migrate-markers", rootCmd.PersistentFlags().Lookup("migrate-markers")))
+	cobra.CheckErr(viper.BindPFlag("filter", rootCmd.PersistentFlags().Lookup("filter")))
+	cobra.CheckErr(viper.BindPFlag("sort", rootCmd.PersistentFlags().Lookup("sort")))
+	cobra.CheckErr(viper.BindPFlag("group-by", rootCmd.PersistentFlags().Lookup("group-by")))
+	cobra.CheckErr(viper.BindPFlag("code-reasons", rootCmd.PersistentFlags().Lookup("code-reasons")))
+	cobra.CheckErr(viper.BindPFlag("format", rootCmd.PersistentFlags().Lookup("format")))
+	cobra.CheckErr(viper.BindPFlag("standalone", rootCmd.PersistentFlags().Lookup("standalone")))
+	cobra.CheckErr(viper.BindPFlag("link-guidelines", rootCmd.PersistentFlags().Lookup("link-guidelines")))
+	cobra.CheckErr(viper.BindPFlag("repo-url", rootCmd.PersistentFlags().Lookup("repo-url")))
+	cobra.CheckErr(viper.BindPFlag("ref", rootCmd.PersistentFlags().Lookup("ref")))
+	cobra.CheckErr(viper.BindPFlag("forge", rootCmd.PersistentFlags().Lookup("forge")))
+	cobra.CheckErr(viper.BindPFlag("file-url-template", rootCmd.PersistentFlags().Lookup("file-url-template")))
+	cobra.CheckErr(viper.BindPFlag("template", rootCmd.PersistentFlags().Lookup("template-file")))
+	cobra.CheckErr(viper.BindPFlag("summary", rootCmd.PersistentFlags().Lookup("summary")))
 	cobra.CheckErr(viper.BindPFlag("verbose", rootCmd.PersistentFlags().Lookup("verbose")))
 	cobra.CheckErr(viper.BindPFlag("dry-run", rootCmd.PersistentFlags().Lookup("dry-run")))
+	cobra.CheckErr(viper.BindPFlag("check", rootCmd.PersistentFlags().Lookup("check")))
 }
 
 // initConfig reads in config file and ENV variables if set.
@@ -142,6 +262,44 @@ func initConfig() error {
 	return nil
 }
 
+// openInputs returns the inputs matching `patterns` and a function closing them
+func openInputs(patterns []string) ([]*cli.Input, func(), error) {
+	paths, err := cli.ExpandInputs(patterns)
+	if err != nil {
+		cmdLogger.Error("failed to expand input files", err.Error())
+		return nil, nil, err
+	}
+
+	inputs := make([]*cli.Input, 0, len(paths))
+	closers := make([]io.Closer, 0, len(paths))
+	closeInputs := func() {
+		for _, closer := range closers {
+			_ = closer.Close()
+		}
+	}
+
+	for _, path := range paths {
+		input, openErr := cli.OpenInput(path)
+		if openErr != nil {
+			closeInputs()
+			cmdLogger.Error("failed to open input file", openErr.Error())
+			return nil, nil, openErr
+		}
+		closers = append(closers, input)
+		inputs = append(inputs, &cli.Input{Name: getInputName(path), Reader: input})
+	}
+
+	return inputs, closeInputs, nil
+}
+
+// getInputName returns the name used to record the source of checkov results read from `path`
+func getInputName(path string) string {
+	if path == config.StdinInput {
+		return "stdin"
+	}
+	return path
+}
+
 // getViperLogLevel returns "DEBUG" if `verbose` flag is used, else it returns "INFO".
 func getLogLevel() string {
 	switch viper.GetBool("verbose") {
@@ -0,0 +1,131 @@
+/*
+Copyright © 2023 The checkov-docs Authors
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package asciidoc
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/checkov-docs/checkov-docs/internal/config"
+	"github.com/checkov-docs/checkov-docs/internal/logger"
+	"github.com/checkov-docs/checkov-docs/internal/models"
+	"github.com/checkov-docs/checkov-docs/internal/table"
+)
+
+// markupPattern matches text that would be rendered as formatting, a macro, an attribute,
+// a cross reference, an index term or a link, or parsed as a comment
+var markupPattern = regexp.MustCompile("[*`#^~+{}\\[\\]\\\\]|://|<<|\\(\\(|^\\s*//|(^|\\W)_|_(\\W|$)")
+
+// urlEscaper escapes the characters that end the target of a link macro
+var urlEscaper = strings.NewReplacer(" ", "%20", "[", "%5B", "]", "%5D")
+
+// passEscaper escapes the characters that end a passthrough macro or a table cell
+var passEscaper = strings.NewReplacer("]", `\]`, "|", `\|`)
+
+// WriteTable returns an asciidoc table with arguments headers and rows, values are escaped
+// revive:disable:unhandled-error writing to a strings.Builder doesn't fail
+func WriteTable(headers []string, rows [][]table.Cell, logger *logger.Logger) (string, error) {
+	logger.Info("create asciidoc table")
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("[cols=\"%d*\",options=\"header\"]\n|===\n", len(headers)))
+	for i, header := range headers {
+		if i > 0 {
+			sb.WriteString(" ")
+		}
+		sb.WriteString("|" + Escape(header))
+	}
+	sb.WriteString("\n")
+	logger.Debug("added header row")
+
+	for _, row := range rows {
+		sb.WriteString("\n")
+		for _, cell := range row {
+			sb.WriteString("|" + FormatCell(cell) + "\n")
+		}
+	}
+	sb.WriteString("|===")
+	logger.Debug("added data rows")
+
+	return sb.String(), nil
+}
+
+// revive:enable:unhandled-error
+
+// WriteSummary returns an asciidoc table with a single row containing the totals in `summary`
+func WriteSummary(headers []string, summary *models.Summary, logger *logger.Logger) (string, error) {
+	logger.Info("create asciidoc summary")
+
+	return WriteTable(headers, [][]table.Cell{table.SummaryRow(summary)}, logger)
+}
+
+// WriteGroup returns `content` below a discrete heading with `title`,
+// which doesn't change the section structure of the document
+func WriteGroup(title, content string) string {
+	if title == "" {
+		title = Escape(config.GroupTitleEmpty)
+	} else {
+		title = code(title)
+	}
+	heading := strings.Repeat("=", config.GroupHeadingLevel+1)
+	return "[discrete]\n" + heading + " " + title + "\n\n" + content
+}
+
+// FormatCell returns the content of `cell` formatted for an asciidoc table cell, as a link if it has a URL
+func FormatCell(cell table.Cell) string {
+	if cell.Value == "" {
+		return ""
+	}
+
+	var text string
+	if cell.Code {
+		text = code(cell.Value)
+	} else {
+		text = Escape(cell.Value)
+	}
+	if cell.URL == "" {
+		return text
+	}
+	// brackets of the text are either escaped or close a passthrough macro
+	return "link:" + urlEscaper.Replace(cell.URL) + "[" + text + "]"
+}
+
+// Escape returns `s` escaped to be rendered as plain text in an asciidoc table cell.
+// Lines containing markup are wrapped in a passthrough macro and new lines are converted to hard line breaks.
+func Escape(s string) string {
+	lines := table.SplitLines(s)
+	for i, line := range lines {
+		if markupPattern.MatchString(line) {
+			lines[i] = "pass:c[" + passEscaper.Replace(line) + "]"
+		} else {
+			lines[i] = strings.ReplaceAll(line, "|", `\|`)
+		}
+	}
+	return strings.Join(lines, " +\n")
+}
+
+// code returns `s` as monospaced text, new lines are replaced with spaces
+func code(s string) string {
+	return "`pass:c[" + passEscaper.Replace(strings.Join(table.SplitLines(s), " ")) + "]`"
+}
@@ -23,75 +23,288 @@ THE SOFTWARE.
 package cli
 
 import (
-	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"os"
-	"path/filepath"
+	texttemplate "text/template"
+
+	"github.com/pmezard/go-difflib/difflib"
 
 	"github.com/checkov-docs/checkov-docs/internal/config"
 	"github.com/checkov-docs/checkov-docs/internal/filewriter"
+	"github.com/checkov-docs/checkov-docs/internal/findings"
+	"github.com/checkov-docs/checkov-docs/internal/html"
+	"github.com/checkov-docs/checkov-docs/internal/links"
 	"github.com/checkov-docs/checkov-docs/internal/logger"
-	"github.com/checkov-docs/checkov-docs/internal/markdown"
 	"github.com/checkov-docs/checkov-docs/internal/models"
+	"github.com/checkov-docs/checkov-docs/internal/parser"
+	"github.com/checkov-docs/checkov-docs/internal/table"
+	"github.com/checkov-docs/checkov-docs/internal/template"
 )
 
-// Generate markdown table from checkov results in `inputFile`
-// and write generated content to `outputFile` which defaults to a 'README.md' file in the current directory.
-func Generate(inputFile, outputFile string, dryRun bool, logger *logger.Logger) error {
+// ErrOutdated is returned in check mode when the output file isn't up to date
+var ErrOutdated = errors.New("output file is out of date")
+
+// ExitCodeOutdated is the exit code of the command when the output file isn't up to date in check mode,
+// other errors exit with 1
+const ExitCodeOutdated = 2
+
+// stdout is the writer of generated content which isn't written to a file
+var stdout io.Writer = os.Stdout
+
+// Options stores the settings used to generate docs
+type Options struct {
+	// InputFormat is the format of checkov results, detected from the content if empty or `auto`
+	InputFormat string
+	// OutputFile is the path to the file where generated content is written, `-` for stdout.
+	// If empty, sections are written to config.DefaultOutputFile, exported data and standalone pages to stdout,
+	// so that a whole file is never replaced unless it's given explicitly.
+	OutputFile string
+	// ResultType is the type of checkov results to document, defaults to skipped checks
+	ResultType string
+	// Section is the name of the section of the output file to write, e.g. `terraform`
+	// for the tags `<!-- BEGIN_CHECKOV_DOCS name=terraform -->`, the unnamed section if empty
+	Section string
+	// Markers are the names and the comment syntax of the tags delimiting the section, see config.GetMarkers
+	Markers config.Markers
+	// MigrateMarkers rewrites the default tags of the section with Markers if the section isn't found
+	MigrateMarkers bool
+	// OutputMode is how the section is written to the output file, see config.OutputModes, the append mode if empty
+	OutputMode string
+	// Backup copies the output file to a `.bak` file before it is modified
+	Backup bool
+	// FollowSymlinks writes the target of the output file if it's a symbolic link, which is an error otherwise
+	FollowSymlinks bool
+	// Columns are the columns of the table, defaults to the columns of `ResultType`, or the exported fields in data formats
+	Columns []config.Column
+	// Filters select the checks to document, e.g. `severity=HIGH,CRITICAL` or `file!=/modules/*`
+	Filters []string
+	// Sort orders the checks by fields, e.g. `severity:desc` then `file`, checkov order is kept if empty
+	Sort []string
+	// GroupBy renders a sub-heading and a table for each value of a field, e.g. `file` or `check_id`
+	GroupBy string
+	// Format is the output format, defaults to markdown. Data formats, e.g. json or csv,
+	// export the fields of the columns of the checks to a whole file, without tags
+	Format string
+	// Standalone renders a whole HTML page instead of a section injected between tags
+	Standalone bool
+	// Links sets the fields rendered as links, e.g. check IDs to their guideline
+	Links links.Options
+	// CodeReasons renders the suppress comments of skipped checks as inline code
+	CodeReasons bool
+	// TemplateFile is the path to a Go template rendering the generated section, see template.Data
+	TemplateFile string
+	// Summary renders the checkov summary block above the table
+	Summary bool
+	// DryRun only prints generated content to stdout
+	DryRun bool
+	// Check compares the output file with generated content without writing it,
+	// a diff is printed and ErrOutdated is returned if they differ
+	Check bool
+}
+
+// Generate markdown table from checkov results read from `inputs`
+// and write generated content to `opts.OutputFile` which defaults to a 'README.md' file in the current directory.
+//
+// Findings of all inputs are merged into a single table and identical findings are only documented once.
+func Generate(inputs []*Input, opts *Options, logger *logger.Logger) error {
+	if len(inputs) == 0 {
+		return errors.New("no checkov results to document")
+	}
 
-	// Read file with checkov results
-	jsonData, err := os.ReadFile(filepath.Clean(inputFile))
+	if err := config.ValidateSection(opts.Section); err != nil {
+		logger.Error("failed to parse section name", err.Error())
+		return err
+	}
+	if err := config.ValidateOutputMode(opts.OutputMode); err != nil {
+		logger.Error("failed to parse output mode", err.Error())
+		return err
+	}
+	if opts.OutputFile == "" {
+		opts = withDefaultOutputFile(opts)
+	}
+	// options set in the opening tag of the section override other options
+	w, opts, err := newFileWriter(opts, logger)
 	if err != nil {
-		logger.Error("failed to read checkov json file", err.Error())
 		return err
 	}
-	logger.Info("read checkov results json data")
 
-	// Parse file with checkov results
-	findings := &models.CheckovResults{}
-	err = json.Unmarshal(jsonData, findings)
+	filters, err := findings.ParseFilters(opts.Filters)
+	if err != nil {
+		logger.Error("failed to parse filters", err.Error())
+		return err
+	}
+	sortKeys, err := findings.ParseSortKeys(opts.Sort)
+	if err != nil {
+		logger.Error("failed to parse sort keys", err.Error())
+		return err
+	}
+	var groupBy string
+	if opts.GroupBy != "" {
+		groupBy, err = findings.GroupFieldName(opts.GroupBy)
+		if err != nil {
+			logger.Error("failed to parse group-by field", err.Error())
+			return err
+		}
+	}
+	format := getFormat(opts.Format)
+	f, err := getFormatter(format, opts.Standalone)
+	if err != nil {
+		logger.Error("failed to select output format", err.Error())
+		return err
+	}
+	columns := opts.Columns
+	if len(columns) == 0 {
+		columns = config.GetColumns(opts.ResultType)
+		if f.writeData != nil {
+			columns = config.ExportColumns
+		}
+	}
+	fields, err := table.Fields(columns)
 	if err != nil {
-		logger.Error("failed to parse checkov json file", err.Error())
+		logger.Error("failed to parse columns", err.Error())
 		return err
 	}
-	logger.Info("parsed checkov results json data")
-
-	// Create markdown header and data rows
-	headers := config.OutputFileHeader
-	rows := make([][]string, len(findings.Results.SkippedChecks))
-	for i, finding := range findings.Results.SkippedChecks {
-		rows[i] = []string{
-			finding.FilePath,
-			finding.CheckID,
-			finding.Resource,
-			finding.CheckResult.SuppressComment,
+	if opts.CodeReasons {
+		columns = setCode(columns, fields, "suppress_comment")
+	}
+	linker, err := links.New(&opts.Links)
+	if err != nil {
+		logger.Error("failed to parse link options", err.Error())
+		return err
+	}
+	var tpl *texttemplate.Template
+	// data formats export the checks as is
+	if opts.TemplateFile != "" && f.writeData == nil {
+		tpl, err = template.Load(opts.TemplateFile)
+		if err != nil {
+			logger.Error("failed to load template file", err.Error())
+			return err
 		}
 	}
-	logger.Debug("created header and data rows", "headers", headers, "rows", rows)
+	readOpts := parser.ReadOptions{
+		ResultTypes: []string{opts.ResultType},
+		// code blocks are only decoded if rendered, templates may render them
+		CodeBlocks: usesField(filters, "code_block") || contains(fields, "code_block") || tpl != nil,
+	}
+
+	reports := make([]*models.CheckovResults, 0, len(inputs))
+	for _, input := range inputs {
+		report, readErr := read(input, readOpts, opts.InputFormat, logger)
+		if readErr != nil {
+			return readErr
+		}
+		reports = append(reports, report)
+	}
+
+	// Merge checkov results
+	results := parser.Merge(reports...)
+	if removed := parser.Deduplicate(results); removed > 0 {
+		logger.Info("removed duplicate checkov results", "count", removed)
+	}
 
-	// Create markdown table
-	table, err := markdown.WriteTable(headers, rows, logger)
+	// Select checks matching the result type to document
+	checks, err := getChecks(results.Results, opts.ResultType)
 	if err != nil {
-		logger.Error("failed to generate markdown table", err.Error())
+		logger.Error("failed to select checkov results", err.Error())
 		return err
 	}
+	checks = findings.Apply(checks, filters)
+	if groupBy != "" {
+		sortKeys = findings.GroupSortKeys(sortKeys, groupBy)
+	}
+	findings.Sort(checks, sortKeys)
+	logger.Debug("selected checkov results", "count", len(checks))
+
+	// Create header and data rows
+	t, err := table.New(columns, checks, linker)
+	if err != nil {
+		logger.Error("failed to create header and data rows", err.Error())
+		return err
+	}
+	logger.Debug("created header and data rows", "headers", t.Headers, "rows", t.Rows)
+
+	// Create table, or a table per group, or export checks
+	var groups []*findings.Group
+	var content string
+	switch {
+	case f.writeData != nil:
+		content, err = f.writeData(fields, checks, logger)
+	case groupBy != "":
+		groups = findings.GroupBy(checks, groupBy)
+		content, err = writeGroups(f, columns, groups, linker, logger)
+	default:
+		content, err = f.writeTable(t.Headers, t.Rows, logger)
+	}
+	if err != nil {
+		logger.Error("failed to generate table", err.Error())
+		return err
+	}
+
+	// Prepend summary
+	if opts.Summary && f.writeData == nil {
+		if results.Summary != nil {
+			summary, summaryErr := f.writeSummary(config.SummaryHeader, results.Summary, logger)
+			if summaryErr != nil {
+				logger.Error("failed to generate summary", summaryErr.Error())
+				return summaryErr
+			}
+			content = summary + "\n\n" + content
+		} else {
+			logger.Warn("checkov results have no summary", "")
+		}
+	}
 
-	if dryRun {
+	// Render user-defined template
+	if tpl != nil {
+		data := &template.Data{
+			Checks:     checks,
+			Table:      t,
+			Groups:     groups,
+			Summary:    results.Summary,
+			Content:    content,
+			ResultType: opts.ResultType,
+			CheckType:  results.CheckType,
+			Inputs:     getInputNames(inputs),
+		}
+		if data.ResultType == "" {
+			data.ResultType = config.ResultTypeSkipped
+		}
+		content, err = template.Render(tpl, data)
+		if err != nil {
+			logger.Error("failed to render template file", err.Error())
+			return err
+		}
+	}
+
+	if opts.Standalone {
+		content = html.WritePage(content)
+	}
+
+	if opts.Check && opts.OutputFile == config.StdoutOutput {
+		return errors.New("check mode requires an output file")
+	}
+
+	if (opts.DryRun && !opts.Check) || opts.OutputFile == config.StdoutOutput {
 		// write generated content to stdout
-		_, err = os.Stdout.WriteString(table)
+		_, err = io.WriteString(stdout, content+"\n")
 		if err != nil {
 			return err
 		}
 	} else {
 		// write generated content to output file
-		w := &filewriter.FileWriter{
-			Filepath:   outputFile,
-			Template:   config.OutputTemplate,
-			OpeningTag: config.TemplateBeginTag,
-			ClosingTag: config.TemplateEndTag,
-			Logger:     logger,
+		if opts.Standalone || f.writeData != nil {
+			// a standalone page or exported data is the whole output file
+			if opts.OutputMode == config.OutputModeInject {
+				return errors.New("inject mode requires a section, standalone pages and data formats replace the whole output file")
+			}
+			w.Template, w.OpeningTag, w.ClosingTag = config.FileTemplate, "", ""
+		}
+		if opts.Check {
+			return check(w, content, logger)
 		}
-		_, err = io.WriteString(w, table)
+		_, err = io.WriteString(w, content)
 		if err != nil {
 			return err
 		}
@@ -100,3 +313,119 @@ func Generate(inputFile, outputFile string, dryRun bool, logger *logger.Logger)
 
 	return nil
 }
+
+// withDefaultOutputFile returns a copy of `opts` with the default output file of the format: exported data
+// and standalone pages replace a whole file, so they're written to stdout, and sections to config.DefaultOutputFile
+func withDefaultOutputFile(opts *Options) *Options {
+	updated := *opts
+	updated.OutputFile = config.DefaultOutputFile
+	if f, ok := formatters[getFormat(opts.Format)]; opts.Standalone || ok && f.writeData != nil {
+		updated.OutputFile = config.StdoutOutput
+	}
+	return &updated
+}
+
+// check compares the content of the output file of `w` with the content it would have once `content` is written.
+// If they differ, a unified diff is printed to stdout and ErrOutdated is returned.
+func check(w *filewriter.FileWriter, content string, logger *logger.Logger) error {
+	current, updated, err := w.Content([]byte(content))
+	if err != nil {
+		logger.Error("failed to generate output file content", err.Error())
+		return err
+	}
+	if current == updated {
+		logger.Info("output file is up to date")
+		return nil
+	}
+
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(current),
+		B:        difflib.SplitLines(updated),
+		FromFile: w.Filepath,
+		ToFile:   w.Filepath + " (generated)",
+		Context:  3,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(stdout, diff)
+	if err != nil {
+		return err
+	}
+	return ErrOutdated
+}
+
+// read returns checkov results read and parsed from `input`.
+// Only checks of the result type to document are decoded.
+func read(input *Input, opts parser.ReadOptions, format string, logger *logger.Logger) (*models.CheckovResults, error) {
+	// Read and parse checkov results
+	logger.Info("read checkov results data", "input", input.Name)
+	report, warnings, err := parser.Read(input.Reader, format, opts)
+	if err != nil {
+		logger.Error("failed to parse checkov results", err.Error())
+		return nil, fmt.Errorf("%s: %w", input.Name, err)
+	}
+	for _, warning := range warnings {
+		logger.Warn("skipped malformed checkov result", input.Name+": "+warning.String())
+	}
+	parser.SetSource(report, input.Name)
+	logger.Info("parsed checkov results data", "input", input.Name)
+
+	return report, nil
+}
+
+// setCode returns a copy of `columns` where columns of the check field `field` are rendered as inline code,
+// `fields` are the check fields of the columns
+func setCode(columns []config.Column, fields []string, field string) []config.Column {
+	updated := make([]config.Column, len(columns))
+	for i, column := range columns {
+		updated[i] = column
+		if fields[i] == field {
+			updated[i].Code = true
+		}
+	}
+	return updated
+}
+
+// getInputNames returns the name of each of `inputs`
+func getInputNames(inputs []*Input) []string {
+	names := make([]string, len(inputs))
+	for i, input := range inputs {
+		names[i] = input.Name
+	}
+	return names
+}
+
+// usesField returns true if any of `filters` matches the check field `name`
+func usesField(filters []*findings.Filter, name string) bool {
+	for _, filter := range filters {
+		if filter.Field == name {
+			return true
+		}
+	}
+	return false
+}
+
+// getChecks returns the checks in `results` matching `resultType`
+func getChecks(results *models.Results, resultType string) ([]*models.Check, error) {
+	switch resultType {
+	case "", config.ResultTypeSkipped:
+		return results.SkippedChecks, nil
+	case config.ResultTypeFailed:
+		return results.FailedChecks, nil
+	case config.ResultTypePassed:
+		return results.PassedChecks, nil
+	default:
+		return nil, fmt.Errorf("invalid result type %q, valid values: %v", resultType, config.ResultTypes)
+	}
+}
+
+// contains returns true if `values` contains `value`
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -25,13 +25,19 @@ package cli
 import (
 	"bytes"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/checkov-docs/checkov-docs/internal/config"
+	"github.com/checkov-docs/checkov-docs/internal/links"
 	"github.com/checkov-docs/checkov-docs/internal/logger"
 )
 
+// Default tags of markdown sections
+var testBeginTag, testEndTag = config.GetMarkers(config.FormatMarkdown, config.Markers{}).Tags("", "")
+
 func TestGenerate_WithSkips(t *testing.T) {
 	assert := assert.New(t)
 
@@ -43,7 +49,7 @@ func TestGenerate_WithSkips(t *testing.T) {
 	logger := logger.NewMockLogger(&bytes.Buffer{})
 
 	// Test generating output file
-	err := Generate(inputFile, tmpOutputFile, false, logger)
+	err := Generate([]*Input{openInput(t, inputFile)}, &Options{OutputFile: tmpOutputFile}, logger)
 	assert.Nil(err, "unexpected error returned by function", err)
 
 	// Assert that the output file exists
@@ -68,7 +74,7 @@ func TestGenerate_NoSkips(t *testing.T) {
 	logger := logger.NewMockLogger(&bytes.Buffer{})
 
 	// Test generating output file
-	err := Generate(inputFile, tmpOutputFile, false, logger)
+	err := Generate([]*Input{openInput(t, inputFile)}, &Options{OutputFile: tmpOutputFile}, logger)
 	assert.Nil(err, "unexpected error returned by function", err)
 
 	// Assert that the output file exists
@@ -82,6 +88,863 @@ func TestGenerate_NoSkips(t *testing.T) {
 	assert.Equal(string(expected), string(output))
 }
 
+func TestGenerate_SummaryOnly(t *testing.T) {
+	assert := assert.New(t)
+
+	// Prepare test data
+	expectedOutputFile := "testdata/no-skips.md"
+	inputFile := "testdata/summary-only.json"
+	tmpOutputFile := createTempFile(t, nil)
+	defer os.Remove(tmpOutputFile)
+	logger := logger.NewMockLogger(&bytes.Buffer{})
+
+	// Test generating output file from checkov results without a results block
+	err := Generate([]*Input{openInput(t, inputFile)}, &Options{OutputFile: tmpOutputFile}, logger)
+	assert.Nil(err, "unexpected error returned by function", err)
+
+	// Assert the content of the output file
+	expected, err := os.ReadFile(expectedOutputFile)
+	assert.Nil(err, "unexpected error reading expected output file", err)
+	output, err := os.ReadFile(tmpOutputFile)
+	assert.Nil(err, "unexpected error reading output file", err)
+	assert.Equal(string(expected), string(output))
+}
+
+func TestGenerate_WithSummary(t *testing.T) {
+	assert := assert.New(t)
+
+	// Prepare test data
+	expectedOutputFile := "testdata/with-summary.md"
+	inputFile := "testdata/with-summary.json"
+	tmpOutputFile := createTempFile(t, nil)
+	defer os.Remove(tmpOutputFile)
+	logger := logger.NewMockLogger(&bytes.Buffer{})
+
+	// Test generating output file with the checkov summary
+	err := Generate([]*Input{openInput(t, inputFile)}, &Options{OutputFile: tmpOutputFile, Summary: true}, logger)
+	assert.Nil(err, "unexpected error returned by function", err)
+
+	// Assert the content of the output file
+	expected, err := os.ReadFile(expectedOutputFile)
+	assert.Nil(err, "unexpected error reading expected output file", err)
+	output, err := os.ReadFile(tmpOutputFile)
+	assert.Nil(err, "unexpected error reading output file", err)
+	assert.Equal(string(expected), string(output))
+}
+
+func TestGenerate_MultiFramework(t *testing.T) {
+	assert := assert.New(t)
+
+	// Prepare test data
+	expectedOutputFile := "testdata/multi-framework.md"
+	inputFile := "testdata/multi-framework.json"
+	tmpOutputFile := createTempFile(t, nil)
+	defer os.Remove(tmpOutputFile)
+	logger := logger.NewMockLogger(&bytes.Buffer{})
+
+	// Test generating output file from an array of checkov results
+	err := Generate([]*Input{openInput(t, inputFile)}, &Options{OutputFile: tmpOutputFile}, logger)
+	assert.Nil(err, "unexpected error returned by function", err)
+
+	// Assert the content of the output file
+	expected, err := os.ReadFile(expectedOutputFile)
+	assert.Nil(err, "unexpected error reading expected output file", err)
+	output, err := os.ReadFile(tmpOutputFile)
+	assert.Nil(err, "unexpected error reading output file", err)
+	assert.Equal(string(expected), string(output))
+}
+
+func TestGenerate_InputFormats(t *testing.T) {
+	tests := []struct {
+		name               string
+		inputFile          string
+		inputFormat        string
+		expectedOutputFile string
+	}{
+		{"json", "testdata/with-skips.json", "json", "testdata/with-skips.md"},
+		{"detected json", "testdata/with-skips.json", "", "testdata/with-skips.md"},
+		{"sarif", "testdata/with-skips.sarif", "sarif", "testdata/with-skips-sarif.md"},
+		{"detected sarif", "testdata/with-skips.sarif", "auto", "testdata/with-skips-sarif.md"},
+		{"junitxml", "testdata/with-skips.xml", "junitxml", "testdata/with-skips-junitxml.md"},
+		{"detected junitxml", "testdata/with-skips.xml", "auto", "testdata/with-skips-junitxml.md"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			// Prepare test data
+			tmpOutputFile := createTempFile(t, nil)
+			defer os.Remove(tmpOutputFile)
+			logger := logger.NewMockLogger(&bytes.Buffer{})
+
+			// Test generating output file
+			err := Generate([]*Input{openInput(t, tt.inputFile)}, &Options{InputFormat: tt.inputFormat, OutputFile: tmpOutputFile}, logger)
+			assert.Nil(err, "unexpected error returned by function", err)
+
+			// Assert the content of the output file
+			expected, err := os.ReadFile(tt.expectedOutputFile)
+			assert.Nil(err, "unexpected error reading expected output file", err)
+			output, err := os.ReadFile(tmpOutputFile)
+			assert.Nil(err, "unexpected error reading output file", err)
+			assert.Equal(string(expected), string(output))
+		})
+	}
+}
+
+func TestGenerate_Reader(t *testing.T) {
+	assert := assert.New(t)
+
+	// Prepare test data
+	expectedOutputFile := "testdata/with-skips.md"
+	data, err := os.ReadFile("testdata/with-skips.json")
+	assert.Nil(err, "unexpected error reading input file", err)
+	tmpOutputFile := createTempFile(t, nil)
+	defer os.Remove(tmpOutputFile)
+	logger := logger.NewMockLogger(&bytes.Buffer{})
+
+	// Test generating output file from checkov results in memory
+	err = Generate([]*Input{{Name: "memory", Reader: bytes.NewReader(data)}}, &Options{OutputFile: tmpOutputFile}, logger)
+	assert.Nil(err, "unexpected error returned by function", err)
+
+	// Assert the content of the output file
+	expected, err := os.ReadFile(expectedOutputFile)
+	assert.Nil(err, "unexpected error reading expected output file", err)
+	output, err := os.ReadFile(tmpOutputFile)
+	assert.Nil(err, "unexpected error reading output file", err)
+	assert.Equal(string(expected), string(output))
+}
+
+func TestGenerate_MultipleInputs(t *testing.T) {
+	assert := assert.New(t)
+
+	// Prepare test data
+	expectedOutputFile := "testdata/multiple-inputs.md"
+	tmpOutputFile := createTempFile(t, nil)
+	defer os.Remove(tmpOutputFile)
+	logger := logger.NewMockLogger(&bytes.Buffer{})
+
+	// Test generating output file from several inputs with duplicate findings
+	inputs := []*Input{
+		openInput(t, "testdata/with-skips.json"),
+		openInput(t, "testdata/multi-framework.json"),
+		openInput(t, "testdata/with-skips.json"),
+	}
+	err := Generate(inputs, &Options{OutputFile: tmpOutputFile}, logger)
+	assert.Nil(err, "unexpected error returned by function", err)
+
+	// Assert the content of the output file
+	expected, err := os.ReadFile(expectedOutputFile)
+	assert.Nil(err, "unexpected error reading expected output file", err)
+	output, err := os.ReadFile(tmpOutputFile)
+	assert.Nil(err, "unexpected error reading output file", err)
+	assert.Equal(string(expected), string(output))
+}
+
+func TestGenerate_NoInputs(t *testing.T) {
+	assert := assert.New(t)
+	logger := logger.NewMockLogger(&bytes.Buffer{})
+
+	// Test generating output without inputs
+	err := Generate(nil, &Options{DryRun: true}, logger)
+	assert.NotNil(err, "expected an error without inputs, but got no error")
+}
+
+func TestGenerate_Filters(t *testing.T) {
+	assert := assert.New(t)
+
+	// Prepare test data
+	expectedOutputFile := "testdata/filtered.md"
+	inputFile := "testdata/multi-framework.json"
+	tmpOutputFile := createTempFile(t, nil)
+	defer os.Remove(tmpOutputFile)
+	logger := logger.NewMockLogger(&bytes.Buffer{})
+
+	// Test generating output file with filtered checks
+	filters := []string{"framework!=kubernetes", "reason=*orchestrator*,*caller*"}
+	err := Generate([]*Input{openInput(t, inputFile)}, &Options{OutputFile: tmpOutputFile, Filters: filters}, logger)
+	assert.Nil(err, "unexpected error returned by function", err)
+
+	// Assert the content of the output file
+	expected, err := os.ReadFile(expectedOutputFile)
+	assert.Nil(err, "unexpected error reading expected output file", err)
+	output, err := os.ReadFile(tmpOutputFile)
+	assert.Nil(err, "unexpected error reading output file", err)
+	assert.Equal(string(expected), string(output))
+
+	// Test generating output with an invalid filter
+	err = Generate([]*Input{openInput(t, inputFile)}, &Options{DryRun: true, Filters: []string{"unknown=value"}}, logger)
+	assert.NotNil(err, "expected an error for an invalid filter, but got no error")
+}
+
+func TestGenerate_Columns(t *testing.T) {
+	assert := assert.New(t)
+
+	// Prepare test data
+	expectedOutputFile := "testdata/columns.md"
+	inputFile := "testdata/with-findings.json"
+	tmpOutputFile := createTempFile(t, nil)
+	defer os.Remove(tmpOutputFile)
+	logger := logger.NewMockLogger(&bytes.Buffer{})
+
+	// Test generating output file with custom columns
+	columns := []config.Column{
+		{Field: "check_id"},
+		{Field: "check_name", Title: "Description"},
+		{Field: "resource_type"},
+	}
+	err := Generate([]*Input{openInput(t, inputFile)}, &Options{OutputFile: tmpOutputFile, ResultType: "failed", Columns: columns}, logger)
+	assert.Nil(err, "unexpected error returned by function", err)
+
+	// Assert the content of the output file
+	expected, err := os.ReadFile(expectedOutputFile)
+	assert.Nil(err, "unexpected error reading expected output file", err)
+	output, err := os.ReadFile(tmpOutputFile)
+	assert.Nil(err, "unexpected error reading output file", err)
+	assert.Equal(string(expected), string(output))
+
+	// Test generating output with an invalid column
+	err = Generate([]*Input{openInput(t, inputFile)}, &Options{DryRun: true, Columns: []config.Column{{Field: "unknown"}}}, logger)
+	assert.NotNil(err, "expected an error for an invalid column, but got no error")
+}
+
+func TestGenerate_TemplateFile(t *testing.T) {
+	assert := assert.New(t)
+
+	// Prepare test data
+	expectedOutputFile := "testdata/template.md"
+	inputFile := "testdata/multi-framework.json"
+	tmpOutputFile := createTempFile(t, nil)
+	defer os.Remove(tmpOutputFile)
+	logger := logger.NewMockLogger(&bytes.Buffer{})
+
+	// Test generating output file with a user-defined template
+	err := Generate([]*Input{openInput(t, inputFile)}, &Options{OutputFile: tmpOutputFile, TemplateFile: "testdata/list.tmpl"}, logger)
+	assert.Nil(err, "unexpected error returned by function", err)
+
+	// Assert the content of the output file
+	expected, err := os.ReadFile(expectedOutputFile)
+	assert.Nil(err, "unexpected error reading expected output file", err)
+	output, err := os.ReadFile(tmpOutputFile)
+	assert.Nil(err, "unexpected error reading output file", err)
+	assert.Equal(string(expected), string(output))
+
+	// Test generating output with a missing template file
+	err = Generate([]*Input{openInput(t, inputFile)}, &Options{DryRun: true, TemplateFile: "testdata/missing.tmpl"}, logger)
+	assert.NotNil(err, "expected an error for a missing template file, but got no error")
+}
+
+func TestGenerate_SortAndGroupBy(t *testing.T) {
+	assert := assert.New(t)
+	logger := logger.NewMockLogger(&bytes.Buffer{})
+	columns := []config.Column{{Field: "check_id"}, {Field: "severity"}, {Field: "resource"}, {Field: "line"}}
+
+	tests := []struct {
+		name               string
+		opts               *Options
+		expectedOutputFile string
+	}{
+		{"sort", &Options{Sort: []string{"file", "line:desc"}}, "testdata/sorted.md"},
+		{"group-by", &Options{Sort: []string{"severity:desc", "resource"}, GroupBy: "file", Columns: columns}, "testdata/grouped.md"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpOutputFile := createTempFile(t, nil)
+			defer os.Remove(tmpOutputFile)
+
+			// Test generating output file with sorted checks
+			tt.opts.OutputFile = tmpOutputFile
+			err := Generate([]*Input{openInput(t, "testdata/grouped.json")}, tt.opts, logger)
+			assert.Nil(err, "unexpected error returned by function", err)
+
+			// Assert the content of the output file
+			expected, err := os.ReadFile(tt.expectedOutputFile)
+			assert.Nil(err, "unexpected error reading expected output file", err)
+			output, err := os.ReadFile(tmpOutputFile)
+			assert.Nil(err, "unexpected error reading output file", err)
+			assert.Equal(string(expected), string(output))
+		})
+	}
+
+	// Test generating output with an invalid sort key and group-by field
+	err := Generate([]*Input{openInput(t, "testdata/grouped.json")}, &Options{DryRun: true, Sort: []string{"file:up"}}, logger)
+	assert.NotNil(err, "expected an error for an invalid sort direction, but got no error")
+	err = Generate([]*Input{openInput(t, "testdata/grouped.json")}, &Options{DryRun: true, GroupBy: "severity"}, logger)
+	assert.NotNil(err, "expected an error for an invalid group-by field, but got no error")
+}
+
+func TestGenerate_HostileInputs(t *testing.T) {
+	assert := assert.New(t)
+	logger := logger.NewMockLogger(&bytes.Buffer{})
+
+	tests := []struct {
+		name               string
+		opts               *Options
+		expectedOutputFile string
+	}{
+		{"escaped", &Options{}, "testdata/hostile.md"},
+		{"inline code", &Options{CodeReasons: true}, "testdata/hostile-code.md"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpOutputFile := createTempFile(t, nil)
+			defer os.Remove(tmpOutputFile)
+
+			// Test generating output file from values breaking tables or injecting HTML
+			tt.opts.OutputFile = tmpOutputFile
+			err := Generate([]*Input{openInput(t, "testdata/hostile.json")}, tt.opts, logger)
+			assert.Nil(err, "unexpected error returned by function", err)
+
+			// Assert the content of the output file
+			expected, err := os.ReadFile(tt.expectedOutputFile)
+			assert.Nil(err, "unexpected error reading expected output file", err)
+			output, err := os.ReadFile(tmpOutputFile)
+			assert.Nil(err, "unexpected error reading output file", err)
+			assert.Equal(string(expected), string(output))
+
+			// Test generating output again, the section must be replaced as a whole
+			err = Generate([]*Input{openInput(t, "testdata/hostile.json")}, tt.opts, logger)
+			assert.Nil(err, "unexpected error returned by function", err)
+			output, err = os.ReadFile(tmpOutputFile)
+			assert.Nil(err, "unexpected error reading output file", err)
+			assert.Equal(string(expected), string(output))
+		})
+	}
+}
+
+func TestGenerate_Unicode(t *testing.T) {
+	assert := assert.New(t)
+
+	// Prepare test data
+	expectedOutputFile := "testdata/unicode.md"
+	inputFile := "testdata/unicode.json"
+	tmpOutputFile := createTempFile(t, nil)
+	defer os.Remove(tmpOutputFile)
+	logger := logger.NewMockLogger(&bytes.Buffer{})
+
+	// Test generating output file with wide and combining characters
+	err := Generate([]*Input{openInput(t, inputFile)}, &Options{OutputFile: tmpOutputFile}, logger)
+	assert.Nil(err, "unexpected error returned by function", err)
+
+	// Assert the content of the output file
+	expected, err := os.ReadFile(expectedOutputFile)
+	assert.Nil(err, "unexpected error reading expected output file", err)
+	output, err := os.ReadFile(tmpOutputFile)
+	assert.Nil(err, "unexpected error reading output file", err)
+	assert.Equal(string(expected), string(output))
+}
+
+func TestGenerate_Links(t *testing.T) {
+	assert := assert.New(t)
+
+	// Prepare test data
+	expectedOutputFile := "testdata/linked.md"
+	inputFile := "testdata/grouped.json"
+	tmpOutputFile := createTempFile(t, nil)
+	defer os.Remove(tmpOutputFile)
+	logger := logger.NewMockLogger(&bytes.Buffer{})
+
+	// Test generating output file with links to guidelines and files
+	linkOpts := links.Options{Guidelines: true, RepoURL: "https://github.com/org/repo", Ref: "main"}
+	err := Generate([]*Input{openInput(t, inputFile)}, &Options{OutputFile: tmpOutputFile, Links: linkOpts}, logger)
+	assert.Nil(err, "unexpected error returned by function", err)
+
+	// Assert the content of the output file
+	expected, err := os.ReadFile(expectedOutputFile)
+	assert.Nil(err, "unexpected error reading expected output file", err)
+	output, err := os.ReadFile(tmpOutputFile)
+	assert.Nil(err, "unexpected error reading output file", err)
+	assert.Equal(string(expected), string(output))
+
+	// Test generating output with an invalid forge
+	linkOpts = links.Options{RepoURL: "https://example.com/org/repo", Forge: "unknown"}
+	err = Generate([]*Input{openInput(t, inputFile)}, &Options{DryRun: true, Links: linkOpts}, logger)
+	assert.NotNil(err, "expected an error for an invalid forge, but got no error")
+}
+
+func TestGenerate_HTML(t *testing.T) {
+	assert := assert.New(t)
+	logger := logger.NewMockLogger(&bytes.Buffer{})
+
+	tests := []struct {
+		name               string
+		inputFile          string
+		opts               *Options
+		expectedOutputFile string
+	}{
+		{"escaped", "testdata/hostile.json", &Options{Format: "html"}, "testdata/hostile-html.md"},
+		{
+			"grouped",
+			"testdata/grouped.json",
+			&Options{Format: "html", GroupBy: "file", Links: links.Options{Guidelines: true, RepoURL: "https://github.com/org/repo"}},
+			"testdata/grouped-html.md",
+		},
+		{"standalone", "testdata/with-summary.json", &Options{Format: "html", Standalone: true, Summary: true}, "testdata/standalone.html"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpOutputFile := createTempFile(t, nil)
+			defer os.Remove(tmpOutputFile)
+
+			// Test generating output file in html, twice to check the section or page is replaced
+			tt.opts.OutputFile = tmpOutputFile
+			for i := 0; i < 2; i++ {
+				err := Generate([]*Input{openInput(t, tt.inputFile)}, tt.opts, logger)
+				assert.Nil(err, "unexpected error returned by function", err)
+			}
+
+			// Assert the content of the output file
+			expected, err := os.ReadFile(tt.expectedOutputFile)
+			assert.Nil(err, "unexpected error reading expected output file", err)
+			output, err := os.ReadFile(tmpOutputFile)
+			assert.Nil(err, "unexpected error reading output file", err)
+			assert.Equal(string(expected), string(output))
+		})
+	}
+
+	// Test generating output with an invalid format
+	err := Generate([]*Input{openInput(t, "testdata/with-skips.json")}, &Options{DryRun: true, Format: "pdf"}, logger)
+	assert.NotNil(err, "expected an error for an invalid format, but got no error")
+
+	// Test generating a standalone page in markdown
+	err = Generate([]*Input{openInput(t, "testdata/with-skips.json")}, &Options{DryRun: true, Standalone: true}, logger)
+	assert.NotNil(err, "expected an error for a standalone markdown page, but got no error")
+}
+
+func TestGenerate_AsciiDocAndRST(t *testing.T) {
+	assert := assert.New(t)
+	logger := logger.NewMockLogger(&bytes.Buffer{})
+	linkOpts := links.Options{Guidelines: true, RepoURL: "https://github.com/org/repo"}
+
+	tests := []struct {
+		name               string
+		inputFile          string
+		opts               *Options
+		expectedOutputFile string
+	}{
+		{"asciidoc escaped", "testdata/hostile.json", &Options{Format: "asciidoc"}, "testdata/hostile.adoc"},
+		{"rst escaped", "testdata/hostile.json", &Options{Format: "rst"}, "testdata/hostile.rst"},
+		{
+			"asciidoc grouped",
+			"testdata/grouped.json",
+			&Options{Format: "asciidoc", GroupBy: "file", CodeReasons: true, Links: linkOpts},
+			"testdata/grouped.adoc",
+		},
+		{
+			"rst grouped",
+			"testdata/grouped.json",
+			&Options{Format: "rst", GroupBy: "file", CodeReasons: true, Links: linkOpts},
+			"testdata/grouped.rst",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpOutputFile := createTempFile(t, nil)
+			defer os.Remove(tmpOutputFile)
+
+			// Test generating output file, twice to check the section between comment tags is replaced
+			tt.opts.OutputFile = tmpOutputFile
+			for i := 0; i < 2; i++ {
+				err := Generate([]*Input{openInput(t, tt.inputFile)}, tt.opts, logger)
+				assert.Nil(err, "unexpected error returned by function", err)
+			}
+
+			// Assert the content of the output file
+			expected, err := os.ReadFile(tt.expectedOutputFile)
+			assert.Nil(err, "unexpected error reading expected output file", err)
+			output, err := os.ReadFile(tmpOutputFile)
+			assert.Nil(err, "unexpected error reading output file", err)
+			assert.Equal(string(expected), string(output))
+		})
+	}
+}
+
+func TestGenerate_DataFormats(t *testing.T) {
+	assert := assert.New(t)
+	logger := logger.NewMockLogger(&bytes.Buffer{})
+	sortKeys, filters := []string{"file:desc", "line"}, []string{"check_id!=CKV_AWS_116"}
+
+	tests := []struct {
+		name               string
+		inputFile          string
+		opts               *Options
+		expectedOutputFile string
+	}{
+		{"json", "testdata/grouped.json", &Options{Format: "json", Sort: sortKeys, Filters: filters}, "testdata/export.json"},
+		{"yaml", "testdata/grouped.json", &Options{Format: "yaml", Sort: sortKeys, Filters: filters, GroupBy: "file"}, "testdata/export.yaml"},
+		{"csv", "testdata/hostile.json", &Options{Format: "csv", Summary: true}, "testdata/export.csv"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Prepare an existing output file with tags, which are replaced as well
+			tmpOutputFile := createTempFile(t, []byte(testBeginTag+"\n\nfoo\n\n"+testEndTag+"\n"))
+			defer os.Remove(tmpOutputFile)
+
+			// Test exporting checks to the whole output file
+			tt.opts.OutputFile = tmpOutputFile
+			err := Generate([]*Input{openInput(t, tt.inputFile)}, tt.opts, logger)
+			assert.Nil(err, "unexpected error returned by function", err)
+
+			// Assert the content of the output file
+			expected, err := os.ReadFile(tt.expectedOutputFile)
+			assert.Nil(err, "unexpected error reading expected output file", err)
+			output, err := os.ReadFile(tmpOutputFile)
+			assert.Nil(err, "unexpected error reading output file", err)
+			assert.Equal(string(expected), string(output))
+		})
+	}
+}
+
+func TestGenerate_Stdout(t *testing.T) {
+	assert := assert.New(t)
+	logger := logger.NewMockLogger(&bytes.Buffer{})
+
+	// Capture content written to stdout
+	var buf bytes.Buffer
+	stdout = &buf
+	defer func() { stdout = os.Stdout }()
+
+	// Test exporting selected columns to stdout
+	columns := []config.Column{{Field: "check_id"}, {Field: "reason", Title: "ignored"}}
+	err := Generate([]*Input{openInput(t, "testdata/grouped.json")}, &Options{OutputFile: "-", Format: "csv", Columns: columns, Filters: []string{"file=/main.tf"}}, logger)
+	assert.Nil(err, "unexpected error returned by function", err)
+	assert.Equal("check_id,suppress_comment\nCKV_AWS_115,concurrency is managed by the caller\nCKV_AWS_116,errors are retried by the caller\n", buf.String())
+	assert.NoFileExists("-")
+
+	// Test exporting to stdout without output file, the default output file isn't replaced
+	buf.Reset()
+	err = Generate([]*Input{openInput(t, "testdata/grouped.json")}, &Options{Format: "json", Columns: columns, Filters: []string{"check_id=CKV_AWS_115"}}, logger)
+	assert.Nil(err, "unexpected error returned by function", err)
+	assert.Equal("[\n  {\n    \"check_id\": \"CKV_AWS_115\",\n    \"suppress_comment\": \"concurrency is managed by the caller\"\n  }\n]\n", buf.String())
+	assert.NoFileExists(config.DefaultOutputFile)
+
+	// Test writing a standalone page to stdout without output file
+	buf.Reset()
+	err = Generate([]*Input{openInput(t, "testdata/with-skips.json")}, &Options{Format: "html", Standalone: true}, logger)
+	assert.Nil(err, "unexpected error returned by function", err)
+	assert.True(strings.HasPrefix(buf.String(), "<!DOCTYPE html>"), "unexpected standalone page %q", buf.String())
+	assert.NoFileExists(config.DefaultOutputFile)
+}
+
+func TestGenerate_Check(t *testing.T) {
+	assert := assert.New(t)
+	logger := logger.NewMockLogger(&bytes.Buffer{})
+	inputFile := "testdata/with-skips.json"
+
+	// Capture content written to stdout
+	var buf bytes.Buffer
+	stdout = &buf
+	defer func() { stdout = os.Stdout }()
+
+	// Prepare an output file with an outdated section
+	outdated := "# Title\n\n" + testBeginTag + "\n\n| File |\n|------|\n\n" + testEndTag + "\n\nfooter\n"
+	tmpOutputFile := createTempFile(t, []byte(outdated))
+	defer os.Remove(tmpOutputFile)
+
+	// Test checking an outdated output file
+	err := Generate([]*Input{openInput(t, inputFile)}, &Options{OutputFile: tmpOutputFile, Check: true}, logger)
+	assert.ErrorIs(err, ErrOutdated)
+	assert.Contains(buf.String(), "--- "+tmpOutputFile+"\n+++ "+tmpOutputFile+" (generated)\n@@ -2,8 +2,9 @@\n")
+	assert.Contains(buf.String(), "\n-| File |\n-|------|\n+| File ")
+	assert.NotContains(buf.String(), "footer")
+
+	// Assert the output file isn't written
+	output, err := os.ReadFile(tmpOutputFile)
+	assert.Nil(err, "unexpected error reading output file", err)
+	assert.Equal(outdated, string(output))
+
+	// Test checking an up to date output file
+	buf.Reset()
+	err = Generate([]*Input{openInput(t, inputFile)}, &Options{OutputFile: tmpOutputFile}, logger)
+	assert.Nil(err, "unexpected error returned by function", err)
+	err = Generate([]*Input{openInput(t, inputFile)}, &Options{OutputFile: tmpOutputFile, Check: true}, logger)
+	assert.Nil(err, "unexpected error checking up to date output file", err)
+	assert.Empty(buf.String())
+
+	// Test checking a missing output file
+	err = Generate([]*Input{openInput(t, inputFile)}, &Options{OutputFile: "missing.md", Check: true}, logger)
+	assert.ErrorIs(err, ErrOutdated)
+	assert.NoFileExists("missing.md")
+
+	// Test checking stdout
+	err = Generate([]*Input{openInput(t, inputFile)}, &Options{OutputFile: "-", Check: true}, logger)
+	assert.NotNil(err, "expected an error when checking stdout, but got no error")
+}
+
+func TestGenerate_Sections(t *testing.T) {
+	assert := assert.New(t)
+	logger := logger.NewMockLogger(&bytes.Buffer{})
+	inputFile := "testdata/multi-framework.json"
+
+	// Prepare an output file with outdated named sections and an unnamed section
+	existing := `# Checkov
+
+## Terraform
+
+<!-- BEGIN_CHECKOV_DOCS name=terraform -->
+
+outdated terraform section
+
+<!-- END_CHECKOV_DOCS name=terraform -->
+
+## Kubernetes
+
+<!-- BEGIN_CHECKOV_DOCS name=kubernetes -->
+<!-- END_CHECKOV_DOCS name=kubernetes -->
+
+## Other
+
+<!-- BEGIN_CHECKOV_DOCS -->
+
+unnamed section
+
+<!-- END_CHECKOV_DOCS -->`
+	tmpOutputFile := createTempFile(t, []byte(existing))
+	defer os.Remove(tmpOutputFile)
+
+	// Test generating each section from its own filter, twice to check each section is replaced independently
+	for i := 0; i < 2; i++ {
+		for _, section := range []string{"terraform", "kubernetes"} {
+			opts := &Options{OutputFile: tmpOutputFile, Section: section, Filters: []string{"framework=" + section}}
+			err := Generate([]*Input{openInput(t, inputFile)}, opts, logger)
+			assert.Nil(err, "unexpected error returned by function", err)
+		}
+	}
+
+	// Assert the content of the output file
+	expected, err := os.ReadFile("testdata/sections.md")
+	assert.Nil(err, "unexpected error reading expected output file", err)
+	output, err := os.ReadFile(tmpOutputFile)
+	assert.Nil(err, "unexpected error reading output file", err)
+	assert.Equal(string(expected), string(output))
+
+	// Test generating a section with an invalid name
+	err = Generate([]*Input{openInput(t, inputFile)}, &Options{DryRun: true, Section: "two words"}, logger)
+	assert.NotNil(err, "expected an error for an invalid section name, but got no error")
+}
+
+func TestGenerate_MarkerOptions(t *testing.T) {
+	assert := assert.New(t)
+	logger := logger.NewMockLogger(&bytes.Buffer{})
+	inputFile := "testdata/grouped.json"
+
+	// Prepare an output file with options in the opening tags
+	existing := `# Checkov
+
+<!-- BEGIN_CHECKOV_DOCS format=list columns=check_id,reason group-by=file filter=severity=HIGH,MEDIUM -->
+
+outdated section
+
+<!-- END_CHECKOV_DOCS -->
+
+<!-- BEGIN_CHECKOV_DOCS name=s3 sort=line:desc columns=line,check_id,reason code-reasons=true -->
+<!-- END_CHECKOV_DOCS name=s3 -->
+`
+	tmpOutputFile := createTempFile(t, []byte(existing))
+	defer os.Remove(tmpOutputFile)
+
+	// Test generating sections with options of the config file overridden by the tags, twice to check tags are kept
+	columns := []config.Column{{Field: "file", Title: "Path"}, {Field: "check_id"}}
+	for i := 0; i < 2; i++ {
+		for _, section := range []string{"", "s3"} {
+			opts := &Options{OutputFile: tmpOutputFile, Section: section, Columns: columns, Filters: []string{"file=/s3.tf"}}
+			err := Generate([]*Input{openInput(t, inputFile)}, opts, logger)
+			assert.Nil(err, "unexpected error returned by function", err)
+		}
+	}
+
+	// Assert the content of the output file
+	expected, err := os.ReadFile("testdata/marker-options.md")
+	assert.Nil(err, "unexpected error reading expected output file", err)
+	output, err := os.ReadFile(tmpOutputFile)
+	assert.Nil(err, "unexpected error reading output file", err)
+	assert.Equal(string(expected), string(output))
+
+	// Test generating sections with invalid options in the tag
+	for _, tag := range []string{"<!-- BEGIN_CHECKOV_DOCS colour=red -->", "<!-- BEGIN_CHECKOV_DOCS format=csv -->", "<!-- BEGIN_CHECKOV_DOCS summary=maybe -->"} {
+		err = os.WriteFile(tmpOutputFile, []byte(tag+"\n"+testEndTag+"\n"), 0644)
+		assert.Nil(err, "unexpected error writing output file", err)
+		err = Generate([]*Input{openInput(t, inputFile)}, &Options{OutputFile: tmpOutputFile}, logger)
+		assert.NotNil(err, "expected an error for invalid options in tag %q, but got no error", tag)
+	}
+}
+
+func TestGenerate_Markers(t *testing.T) {
+	assert := assert.New(t)
+	logger := logger.NewMockLogger(&bytes.Buffer{})
+	inputFile := "testdata/grouped.json"
+
+	// Prepare an output file with custom tags in the hcl comment syntax
+	existing := `# Checkov
+
+# BEGIN_SECURITY name=s3 format=list columns=check_id
+outdated section
+# END_SECURITY name=s3
+
+<!-- BEGIN_CHECKOV_DOCS -->
+<!-- END_CHECKOV_DOCS -->
+`
+	tmpOutputFile := createTempFile(t, []byte(existing))
+	defer os.Remove(tmpOutputFile)
+
+	// Test generating the section delimited by the custom tags, twice to check tags are kept
+	syntax, err := config.ParseCommentSyntax(config.CommentSyntaxHCL)
+	assert.Nil(err, "unexpected error parsing comment syntax", err)
+	markers := config.Markers{Begin: "BEGIN_SECURITY", End: "END_SECURITY", Syntax: syntax}
+	for i := 0; i < 2; i++ {
+		opts := &Options{OutputFile: tmpOutputFile, Section: "s3", Markers: markers, Filters: []string{"file=/s3.tf"}}
+		err = Generate([]*Input{openInput(t, inputFile)}, opts, logger)
+		assert.Nil(err, "unexpected error returned by function", err)
+	}
+
+	// Assert the content of the output file
+	output, err := os.ReadFile(tmpOutputFile)
+	assert.Nil(err, "unexpected error reading output file", err)
+	expected := "# BEGIN_SECURITY name=s3 format=list columns=check_id\n\n- CKV_AWS_21\n- CKV_AWS_18\n- CKV_AWS_18\n\n# END_SECURITY name=s3\n"
+	assert.Contains(string(output), expected)
+	assert.Contains(string(output), "<!-- BEGIN_CHECKOV_DOCS -->\n<!-- END_CHECKOV_DOCS -->\n", "unexpected change of the section with default tags")
+
+	// Test invalid tags
+	for _, invalid := range []config.Markers{{Begin: "BEGIN DOCS"}, {Begin: "DOCS", End: "DOCS"}} {
+		err = Generate([]*Input{openInput(t, inputFile)}, &Options{OutputFile: tmpOutputFile, Markers: invalid}, logger)
+		assert.NotNil(err, "expected an error for tags %+v, but got no error", invalid)
+	}
+}
+
+func TestGenerate_MigrateMarkers(t *testing.T) {
+	assert := assert.New(t)
+	logger := logger.NewMockLogger(&bytes.Buffer{})
+	inputFile := "testdata/with-findings.json"
+
+	// Prepare an output file with the default tags and options
+	existing := `# Checkov
+
+<!-- BEGIN_CHECKOV_DOCS_FAILED format=list columns=check_id -->
+outdated section
+<!-- END_CHECKOV_DOCS_FAILED -->
+`
+	tmpOutputFile := createTempFile(t, []byte(existing))
+	defer os.Remove(tmpOutputFile)
+
+	// Test migrating the default tags to custom tags, twice to check the migrated section is found
+	markers := config.Markers{Begin: "BEGIN_SECURITY", End: "END_SECURITY"}
+	for i := 0; i < 2; i++ {
+		opts := &Options{OutputFile: tmpOutputFile, ResultType: config.ResultTypeFailed, Markers: markers, MigrateMarkers: true}
+		err := Generate([]*Input{openInput(t, inputFile)}, opts, logger)
+		assert.Nil(err, "unexpected error returned by function", err)
+	}
+
+	// Assert the content of the output file
+	output, err := os.ReadFile(tmpOutputFile)
+	assert.Nil(err, "unexpected error reading output file", err)
+	assert.True(strings.HasPrefix(string(output), "# Checkov\n\n<!-- BEGIN_SECURITY_FAILED format=list columns=check_id -->\n\n- "), "unexpected migrated opening tag in %q", output)
+	assert.True(strings.HasSuffix(string(output), "\n\n<!-- END_SECURITY_FAILED -->\n"), "unexpected migrated closing tag in %q", output)
+	assert.NotContains(string(output), "CHECKOV_DOCS")
+
+	// Test the default tags are kept without migration
+	err = os.WriteFile(tmpOutputFile, []byte(existing), 0644)
+	assert.Nil(err, "unexpected error writing output file", err)
+	opts := &Options{OutputFile: tmpOutputFile, ResultType: config.ResultTypeFailed, Markers: markers}
+	err = Generate([]*Input{openInput(t, inputFile)}, opts, logger)
+	assert.Nil(err, "unexpected error returned by function", err)
+	output, err = os.ReadFile(tmpOutputFile)
+	assert.Nil(err, "unexpected error reading output file", err)
+	assert.True(strings.HasPrefix(string(output), existing), "unexpected change of the section with default tags in %q", output)
+	assert.Contains(string(output), "<!-- BEGIN_SECURITY_FAILED -->")
+}
+
+func TestGenerate_OutputModes(t *testing.T) {
+	assert := assert.New(t)
+	logger := logger.NewMockLogger(&bytes.Buffer{})
+	inputFile := "testdata/with-skips.json"
+
+	// Prepare an output file without tags
+	existing := "# Title\n"
+	tmpOutputFile := createTempFile(t, []byte(existing))
+	defer os.Remove(tmpOutputFile)
+
+	// Test inject mode fails without writing the output file
+	opts := &Options{OutputFile: tmpOutputFile, OutputMode: config.OutputModeInject}
+	err := Generate([]*Input{openInput(t, inputFile)}, opts, logger)
+	assert.NotNil(err, "expected an error in inject mode without tags, but got no error")
+	output, err := os.ReadFile(tmpOutputFile)
+	assert.Nil(err, "unexpected error reading output file", err)
+	assert.Equal(existing, string(output))
+
+	// Test prepend mode, then inject mode once the section exists
+	for _, mode := range []string{config.OutputModePrepend, config.OutputModeInject} {
+		opts = &Options{OutputFile: tmpOutputFile, OutputMode: mode}
+		err = Generate([]*Input{openInput(t, inputFile)}, opts, logger)
+		assert.Nil(err, "unexpected error in mode %q", mode)
+		output, err = os.ReadFile(tmpOutputFile)
+		assert.Nil(err, "unexpected error reading output file", err)
+		assert.True(strings.HasPrefix(string(output), testBeginTag), "unexpected start of output file in mode %q: %q", mode, output)
+		assert.True(strings.HasSuffix(string(output), testEndTag+"\n\n"+existing), "unexpected end of output file in mode %q: %q", mode, output)
+	}
+
+	// Test invalid modes
+	for _, opts := range []*Options{
+		{OutputFile: tmpOutputFile, OutputMode: "merge"},
+		{OutputFile: tmpOutputFile, OutputMode: config.OutputModeInject, Format: config.FormatCSV},
+	} {
+		err = Generate([]*Input{openInput(t, inputFile)}, opts, logger)
+		assert.NotNil(err, "expected an error for options %+v, but got no error", opts)
+	}
+}
+
+func TestGenerate_FailedAndSkipped(t *testing.T) {
+	assert := assert.New(t)
+
+	// Prepare test data
+	inputFile := "testdata/with-findings.json"
+	tmpOutputFile := createTempFile(t, nil)
+	defer os.Remove(tmpOutputFile)
+	logger := logger.NewMockLogger(&bytes.Buffer{})
+
+	// Test generating failed and skipped sections in the same output file
+	err := Generate([]*Input{openInput(t, inputFile)}, &Options{OutputFile: tmpOutputFile, ResultType: "failed"}, logger)
+	assert.Nil(err, "unexpected error returned by function", err)
+	err = Generate([]*Input{openInput(t, inputFile)}, &Options{OutputFile: tmpOutputFile, ResultType: "skipped"}, logger)
+	assert.Nil(err, "unexpected error returned by function", err)
+
+	// Assert the content of the output file
+	expectedFailed, err := os.ReadFile("testdata/with-failed.md")
+	assert.Nil(err, "unexpected error reading expected output file", err)
+	expectedSkipped, err := os.ReadFile("testdata/with-skips.md")
+	assert.Nil(err, "unexpected error reading expected output file", err)
+	output, err := os.ReadFile(tmpOutputFile)
+	assert.Nil(err, "unexpected error reading output file", err)
+	assert.Equal(string(expectedFailed)+"\n"+string(expectedSkipped), string(output))
+}
+
+func TestGenerate_InvalidResultType(t *testing.T) {
+	assert := assert.New(t)
+
+	// Prepare test data
+	inputFile := "testdata/with-findings.json"
+	logger := logger.NewMockLogger(&bytes.Buffer{})
+
+	// Test generating output with an unknown result type
+	err := Generate([]*Input{openInput(t, inputFile)}, &Options{ResultType: "unknown", DryRun: true}, logger)
+	assert.NotNil(err, "expected an error for an invalid result type, but got no error")
+}
+
+// Helper function to open an input file which is closed when the test finishes
+func openInput(t *testing.T, path string) *Input {
+	input, err := OpenInput(path)
+	if err != nil {
+		t.Fatalf("Failed to open input file: %s", err.Error())
+	}
+	t.Cleanup(func() { input.Close() })
+
+	return &Input{Name: path, Reader: input}
+}
+
 // Helper function to create a temporary file and write content to it
 func createTempFile(t *testing.T, content []byte) string {
 	tmpFile, err := os.CreateTemp(".", "testfile")
@@ -0,0 +1,125 @@
+/*
+Copyright © 2023 The checkov-docs Authors
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/checkov-docs/checkov-docs/internal/asciidoc"
+	"github.com/checkov-docs/checkov-docs/internal/config"
+	"github.com/checkov-docs/checkov-docs/internal/export"
+	"github.com/checkov-docs/checkov-docs/internal/findings"
+	"github.com/checkov-docs/checkov-docs/internal/html"
+	"github.com/checkov-docs/checkov-docs/internal/links"
+	"github.com/checkov-docs/checkov-docs/internal/logger"
+	"github.com/checkov-docs/checkov-docs/internal/markdown"
+	"github.com/checkov-docs/checkov-docs/internal/models"
+	"github.com/checkov-docs/checkov-docs/internal/rst"
+	"github.com/checkov-docs/checkov-docs/internal/table"
+)
+
+// formatter renders tables of checks in an output format,
+// or exports the checks as data if writeData is set
+type formatter struct {
+	// writeTable returns a table with headers and rows
+	writeTable func(headers []string, rows [][]table.Cell, logger *logger.Logger) (string, error)
+	// writeSummary returns a table with the totals of a summary
+	writeSummary func(headers []string, summary *models.Summary, logger *logger.Logger) (string, error)
+	// writeGroup returns the content of a group of checks with its title
+	writeGroup func(title, content string) string
+	// writeData returns the values of fields of checks, written as a whole file
+	writeData func(fields []string, checks []*models.Check, logger *logger.Logger) (string, error)
+}
+
+// formatters stores the formatter of each output format
+var formatters = map[string]*formatter{
+	config.FormatMarkdown: {
+		writeTable:   markdown.WriteCells,
+		writeSummary: markdown.WriteSummary,
+		writeGroup:   markdown.WriteGroup,
+	},
+	config.FormatHTML: {
+		writeTable:   html.WriteTable,
+		writeSummary: html.WriteSummary,
+		writeGroup:   html.WriteGroup,
+	},
+	config.FormatAsciiDoc: {
+		writeTable:   asciidoc.WriteTable,
+		writeSummary: asciidoc.WriteSummary,
+		writeGroup:   asciidoc.WriteGroup,
+	},
+	config.FormatRST: {
+		writeTable:   rst.WriteTable,
+		writeSummary: rst.WriteSummary,
+		writeGroup:   rst.WriteGroup,
+	},
+	config.FormatList: {
+		writeTable:   markdown.WriteList,
+		writeSummary: markdown.WriteSummary,
+		writeGroup:   markdown.WriteGroup,
+	},
+	config.FormatJSON: {writeData: export.WriteJSON},
+	config.FormatYAML: {writeData: export.WriteYAML},
+	config.FormatCSV:  {writeData: export.WriteCSV},
+}
+
+// getFormat returns the lowercase output format `format`, markdown if empty
+func getFormat(format string) string {
+	if format == "" {
+		return config.FormatMarkdown
+	}
+	return strings.ToLower(format)
+}
+
+// getFormatter returns the formatter of `format`.
+// Standalone pages are only available in HTML.
+func getFormatter(format string, standalone bool) (*formatter, error) {
+	f, ok := formatters[format]
+	if !ok {
+		return nil, fmt.Errorf("invalid format %q, valid values: %v", format, config.Formats)
+	}
+	if standalone && f != formatters[config.FormatHTML] {
+		return nil, errors.New("standalone pages are only available in html format")
+	}
+	return f, nil
+}
+
+// writeGroups returns a table with a title for each of `groups`
+func writeGroups(f *formatter, columns []config.Column, groups []*findings.Group, linker *links.Linker, logger *logger.Logger) (string, error) {
+	sections := make([]string, len(groups))
+	for i, group := range groups {
+		t, err := table.New(columns, group.Checks, linker)
+		if err != nil {
+			return "", err
+		}
+		content, err := f.writeTable(t.Headers, t.Rows, logger)
+		if err != nil {
+			return "", err
+		}
+		sections[i] = f.writeGroup(group.Key, content)
+	}
+
+	return strings.Join(sections, "\n\n"), nil
+}
@@ -0,0 +1,229 @@
+/*
+Copyright © 2023 The checkov-docs Authors
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package cli
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/checkov-docs/checkov-docs/internal/config"
+)
+
+// Input is a source of checkov results
+type Input struct {
+	// Name identifies the source of checkov results, e.g. the path of the input file
+	Name string
+	// Reader is used to read checkov results
+	Reader io.Reader
+}
+
+// OpenInput returns a reader for the checkov results in `path`.
+// If `path` is `-`, checkov results are read from stdin.
+func OpenInput(path string) (io.ReadCloser, error) {
+	if path == config.StdinInput {
+		// stdin is not closed by the caller
+		return io.NopCloser(os.Stdin), nil
+	}
+
+	return os.Open(filepath.Clean(path))
+}
+
+// IsPiped returns true if `f` is not a terminal, i.e. data is piped or redirected to it
+func IsPiped(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+
+	return info.Mode()&os.ModeCharDevice == 0
+}
+
+// ExpandInputs returns the paths of the input files matching `patterns`.
+//
+// Patterns support the syntax of `path.Match` and `**` to match any number of directories,
+// e.g. `reports/**/*.json`. Patterns without meta characters and `-` are returned as is.
+// Each path is only returned once, in the order of the patterns that match it.
+func ExpandInputs(patterns []string) ([]string, error) {
+	var paths []string
+	seen := make(map[string]bool)
+
+	for _, pattern := range patterns {
+		matches := []string{pattern}
+		if pattern != config.StdinInput && hasMeta(pattern) {
+			var err error
+			matches, err = glob(pattern)
+			if err != nil {
+				return nil, err
+			}
+			if len(matches) == 0 {
+				return nil, fmt.Errorf("no input files match pattern %q", pattern)
+			}
+		}
+
+		for _, match := range matches {
+			if !seen[match] {
+				seen[match] = true
+				paths = append(paths, match)
+			}
+		}
+	}
+
+	return paths, nil
+}
+
+// glob returns the regular files matching `pattern` in lexical order.
+// Patterns without `**` are expanded with filepath.Glob, other patterns walk the directories they can match.
+func glob(pattern string) ([]string, error) {
+	pattern = filepath.Clean(pattern)
+	if _, err := path.Match(filepath.ToSlash(pattern), ""); err != nil {
+		return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+	}
+
+	segments := strings.Split(filepath.ToSlash(pattern), "/")
+	if !hasRecursive(segments) {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+		return regularFiles(matches), nil
+	}
+
+	return walkGlob(globRoot(segments), segments)
+}
+
+// globRoot returns the longest directory prefix of pattern segments `pattern` without meta characters
+func globRoot(pattern []string) string {
+	base := 0
+	for base < len(pattern)-1 && !hasMeta(pattern[base]) {
+		base++
+	}
+	root := strings.Join(pattern[:base], "/")
+	if root == "" {
+		root = "."
+		if pattern[0] == "" {
+			root = "/"
+		}
+	}
+	return filepath.FromSlash(root)
+}
+
+// walkGlob returns the files below `root` matching pattern segments `pattern` in lexical order.
+// Directories that can't match are skipped without being read.
+func walkGlob(root string, pattern []string) ([]string, error) {
+	var matches []string
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if d == nil {
+			// the root doesn't exist or can't be read, so nothing matches
+			return nil
+		}
+		name := strings.Split(filepath.ToSlash(p), "/")
+		switch {
+		case d.IsDir() && p != root && !matchPrefix(pattern, name):
+			// neither the directory nor its descendants can match
+			return fs.SkipDir
+		case !d.IsDir() && matchSegments(pattern, name):
+			matches = append(matches, p)
+		}
+		// directories that can match but can't be read are reported
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return matches, nil
+}
+
+// regularFiles returns the paths of `paths` that aren't directories
+func regularFiles(paths []string) []string {
+	files := paths[:0]
+	for _, p := range paths {
+		if info, err := os.Stat(p); err == nil && info.IsDir() {
+			continue
+		}
+		files = append(files, p)
+	}
+	return files
+}
+
+// hasRecursive returns true if pattern segments `pattern` contain a `**` segment
+func hasRecursive(pattern []string) bool {
+	for _, segment := range pattern {
+		if segment == "**" {
+			return true
+		}
+	}
+	return false
+}
+
+// matchPrefix returns true if the files below the directory with path segments `name`
+// can match pattern segments `pattern`
+func matchPrefix(pattern, name []string) bool {
+	for ; len(name) > 0; pattern, name = pattern[1:], name[1:] {
+		if len(pattern) == 0 {
+			return false
+		}
+		if pattern[0] == "**" {
+			return true
+		}
+		if ok, _ := path.Match(pattern[0], name[0]); !ok {
+			return false
+		}
+	}
+
+	return len(pattern) > 0
+}
+
+// matchSegments returns true if path segments `name` match pattern segments `pattern`,
+// a `**` segment matches zero or more path segments
+func matchSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(name); i++ {
+				if matchSegments(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], name[0]); !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+
+	return len(name) == 0
+}
+
+// hasMeta returns true if `pattern` contains any of the meta characters recognized by `path.Match`
+func hasMeta(pattern string) bool {
+	return strings.ContainsAny(pattern, "*?[")
+}
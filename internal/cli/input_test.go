@@ -0,0 +1,131 @@
+/*
+Copyright © 2023 The checkov-docs Authors
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package cli
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenInput(t *testing.T) {
+	assert := assert.New(t)
+
+	// Test opening an input file
+	input, err := OpenInput("testdata/with-skips.json")
+	assert.Nil(err, "unexpected error opening input file", err)
+	data, err := io.ReadAll(input)
+	assert.Nil(err, "unexpected error reading input file", err)
+	assert.Contains(string(data), "CKV_AWS_115")
+	assert.Nil(input.Close())
+
+	// Test opening stdin, closing it must not close stdin
+	input, err = OpenInput("-")
+	assert.Nil(err, "unexpected error opening stdin", err)
+	assert.Nil(input.Close())
+	_, err = os.Stdin.Stat()
+	assert.Nil(err, "stdin was closed", err)
+
+	// Test opening a non-existent input file
+	_, err = OpenInput("testdata/non-existent.json")
+	assert.NotNil(err, "expected an error opening a non-existent input file, but got no error")
+}
+
+func TestIsPiped(t *testing.T) {
+	assert := assert.New(t)
+
+	// Test that regular files are piped
+	f, err := os.Open("testdata/with-skips.json")
+	assert.Nil(err, "unexpected error opening input file", err)
+	defer f.Close()
+	assert.True(IsPiped(f))
+
+	// Test that character devices are not piped
+	devNull, err := os.Open(os.DevNull)
+	assert.Nil(err, "unexpected error opening null device", err)
+	defer devNull.Close()
+	assert.False(IsPiped(devNull))
+}
+
+func TestExpandInputs(t *testing.T) {
+	assert := assert.New(t)
+
+	// Prepare test data
+	dir := t.TempDir()
+	for _, name := range []string{"a/results.json", "a/b/results.json", "a/b/c/results.json", "a/results.xml", "results.json"} {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		assert.Nil(os.MkdirAll(filepath.Dir(path), 0755))
+		assert.Nil(os.WriteFile(path, []byte("{}"), 0644))
+	}
+	join := func(name string) string {
+		return filepath.Join(dir, filepath.FromSlash(name))
+	}
+
+	// Test expanding recursive and single-level patterns
+	paths, err := ExpandInputs([]string{join("a/**/*.json")})
+	assert.Nil(err, "unexpected error expanding inputs", err)
+	assert.Equal([]string{join("a/b/c/results.json"), join("a/b/results.json"), join("a/results.json")}, paths)
+
+	paths, err = ExpandInputs([]string{join("*/results.*")})
+	assert.Nil(err, "unexpected error expanding inputs", err)
+	assert.Equal([]string{join("a/results.json"), join("a/results.xml")}, paths)
+
+	// Test that literal paths and stdin are kept and duplicates are removed
+	paths, err = ExpandInputs([]string{"-", join("results.json"), join("*.json"), join("a/results.json")})
+	assert.Nil(err, "unexpected error expanding inputs", err)
+	assert.Equal([]string{"-", join("results.json"), join("a/results.json")}, paths)
+
+	// Test that directories that can't match aren't read, even if they are unreadable
+	assert.Nil(os.Mkdir(join("a/locked"), 0))
+	paths, err = ExpandInputs([]string{join("*/b/**/*.json")})
+	assert.Nil(err, "unexpected error expanding inputs", err)
+	assert.Equal([]string{join("a/b/c/results.json"), join("a/b/results.json")}, paths)
+
+	// Test patterns without matches
+	_, err = ExpandInputs([]string{join("**/*.sarif")})
+	assert.NotNil(err, "expected an error for a pattern without matches, but got no error")
+}
+
+func TestMatchSegments(t *testing.T) {
+	assert := assert.New(t)
+
+	// Test matching path segments
+	assert.True(matchSegments([]string{"**", "*.json"}, []string{"results.json"}))
+	assert.True(matchSegments([]string{"reports", "**", "*.json"}, []string{"reports", "a", "b", "results.json"}))
+	assert.True(matchSegments([]string{"reports", "**"}, []string{"reports", "a", "results.json"}))
+	assert.False(matchSegments([]string{"reports", "*.json"}, []string{"reports", "a", "results.json"}))
+	assert.False(matchSegments([]string{"reports", "**", "*.json"}, []string{"other", "results.json"}))
+}
+
+func TestMatchPrefix(t *testing.T) {
+	assert := assert.New(t)
+
+	// Test matching directories whose files can match a pattern
+	assert.True(matchPrefix([]string{"reports", "*", "*.json"}, []string{"reports", "a"}))
+	assert.True(matchPrefix([]string{"reports", "**", "*.json"}, []string{"reports", "a", "b"}))
+	assert.False(matchPrefix([]string{"reports", "*", "*.json"}, []string{"reports", "a", "b"}))
+	assert.False(matchPrefix([]string{"reports", "*.json"}, []string{"other"}))
+}
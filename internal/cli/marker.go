@@ -0,0 +1,215 @@
+/*
+Copyright © 2023 The checkov-docs Authors
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/checkov-docs/checkov-docs/internal/config"
+	"github.com/checkov-docs/checkov-docs/internal/filewriter"
+	"github.com/checkov-docs/checkov-docs/internal/logger"
+)
+
+// markerOptions stores the setters of the options which can be set in the opening tag of a section,
+// e.g. `<!-- BEGIN_CHECKOV_DOCS format=list columns=check_id,reason group-by=file -->`
+var markerOptions = map[string]func(opts *Options, value string) error{
+	"format": func(opts *Options, value string) error {
+		// the tag delimits a section, the whole file can't be replaced
+		if f, ok := formatters[strings.ToLower(value)]; !ok || f.writeData != nil {
+			return fmt.Errorf("invalid format %q, valid values: %v", value, sectionFormats())
+		}
+		opts.Format = value
+		return nil
+	},
+	"columns": func(opts *Options, value string) error {
+		opts.Columns = nil
+		for _, field := range splitList(value) {
+			opts.Columns = append(opts.Columns, config.Column{Field: field})
+		}
+		return nil
+	},
+	"sort": func(opts *Options, value string) error {
+		opts.Sort = splitList(value)
+		return nil
+	},
+	"group-by": func(opts *Options, value string) error {
+		opts.GroupBy = value
+		return nil
+	},
+	"filter": func(opts *Options, value string) error {
+		opts.Filters = append(opts.Filters, value)
+		return nil
+	},
+	"code-reasons": func(opts *Options, value string) (err error) {
+		opts.CodeReasons, err = strconv.ParseBool(value)
+		return err
+	},
+	"summary": func(opts *Options, value string) (err error) {
+		opts.Summary, err = strconv.ParseBool(value)
+		return err
+	},
+}
+
+// newFileWriter returns the writer of the section of the output file, and `opts` overridden by the options
+// of the opening tag of the section in the output file. The opening tag is kept as is when the section is written.
+//
+// If the section isn't found and `opts.MigrateMarkers` is set, the section delimited by the default tags
+// of the format is rewritten with the configured tags, keeping the options of its opening tag.
+func newFileWriter(opts *Options, logger *logger.Logger) (*filewriter.FileWriter, *Options, error) {
+	format := getFormat(opts.Format)
+	markers := config.GetMarkers(format, opts.Markers)
+	if err := markers.Validate(); err != nil {
+		logger.Error("failed to parse section tags", err.Error())
+		return nil, nil, err
+	}
+	openingTag, closingTag := markers.Tags(opts.ResultType, opts.Section)
+	w := &filewriter.FileWriter{
+		Filepath:       opts.OutputFile,
+		Template:       config.GetTagsTemplate(openingTag, closingTag),
+		OpeningTag:     openingTag,
+		ClosingTag:     closingTag,
+		Mode:           opts.OutputMode,
+		Backup:         opts.Backup,
+		FollowSymlinks: opts.FollowSymlinks,
+		LineTags:       markers.Syntax.Suffix == "",
+		Logger:         logger,
+	}
+
+	// stdout, standalone pages and exported data don't have sections
+	f, ok := formatters[format]
+	if !ok || f.writeData != nil || opts.Standalone || opts.OutputFile == config.StdoutOutput {
+		return w, opts, nil
+	}
+
+	marker, err := w.FindMarker()
+	if err != nil {
+		logger.Error("failed to read the section tag of the output file", err.Error())
+		return nil, nil, err
+	}
+	if marker != nil {
+		// the opening tag is rewritten as is
+		w.Template = config.GetTagsTemplate(marker.Tag, closingTag)
+	} else if opts.MigrateMarkers {
+		marker, err = findDefaultMarker(w, format, markers, opts)
+		if err != nil {
+			logger.Error("failed to read the section tag of the output file", err.Error())
+			return nil, nil, err
+		}
+	}
+	if marker == nil {
+		return w, opts, nil
+	}
+	w.OpeningTag = marker.Tag
+	if len(marker.Options) == 0 {
+		return w, opts, nil
+	}
+
+	updated, err := applyOptions(opts, marker.Options)
+	if err != nil {
+		logger.Error("failed to parse the options of the section tag", err.Error())
+		return nil, nil, fmt.Errorf("invalid options in tag %q: %w", marker.Tag, err)
+	}
+	logger.Info("apply options of the section tag", "tag", marker.Tag)
+	return w, updated, nil
+}
+
+// findDefaultMarker returns the opening tag of the section delimited by the default tags of `format`, or nil
+// if there is none or `markers` are the default tags. If it's found, `w` is set to replace the default tags
+// of the section with `markers`, and the options of the opening tag are kept.
+func findDefaultMarker(w *filewriter.FileWriter, format string, markers config.Markers, opts *Options) (*filewriter.Marker, error) {
+	defaults := config.GetMarkers(format, config.Markers{})
+	if defaults.Begin == markers.Begin && defaults.End == markers.End && *defaults.Syntax == *markers.Syntax {
+		return nil, nil
+	}
+
+	openingTag, closingTag := defaults.Tags(opts.ResultType, opts.Section)
+	finder := &filewriter.FileWriter{Filepath: w.Filepath, OpeningTag: openingTag, Logger: w.Logger}
+	marker, err := finder.FindMarker()
+	if marker == nil || err != nil {
+		return nil, err
+	}
+
+	options := make([]string, len(marker.Options))
+	for i, option := range marker.Options {
+		options[i] = option.Key + "=" + option.Value
+	}
+	w.Template = config.GetTagsTemplate(markers.Tags(opts.ResultType, opts.Section, options...))
+	w.ClosingTag = closingTag
+	w.Logger.Info("migrate section tags", "from", marker.Tag)
+	return marker, nil
+}
+
+// applyOptions returns a copy of `opts` with the values of `options`, which override flags and the config file.
+// Filters of the options replace the other filters.
+func applyOptions(opts *Options, options []filewriter.Option) (*Options, error) {
+	updated := *opts
+	updated.Filters = nil
+	for _, option := range options {
+		set, ok := markerOptions[option.Key]
+		if !ok {
+			return nil, fmt.Errorf("unknown option %q, valid options: %v", option.Key, markerOptionKeys())
+		}
+		if err := set(&updated, option.Value); err != nil {
+			return nil, fmt.Errorf("option %q: %w", option.Key, err)
+		}
+	}
+	if updated.Filters == nil {
+		updated.Filters = opts.Filters
+	}
+	return &updated, nil
+}
+
+// markerOptionKeys returns the sorted keys of the options of section tags
+func markerOptionKeys() []string {
+	keys := make([]string, 0, len(markerOptions))
+	for key := range markerOptions {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// sectionFormats returns the formats that can be written in a section
+func sectionFormats() []string {
+	var formats []string
+	for _, format := range config.Formats {
+		if f := formatters[format]; f.writeData == nil {
+			formats = append(formats, format)
+		}
+	}
+	return formats
+}
+
+// splitList returns the trimmed and non-empty values of the comma-separated list `value`
+func splitList(value string) []string {
+	var values []string
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
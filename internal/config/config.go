@@ -23,22 +23,343 @@ THE SOFTWARE.
 package config
 
 import (
+	"errors"
 	"fmt"
+	"regexp"
 	"strings"
 )
 
-// Values used to generate template string
+// templateDataStructure is the action of templates rendering generated content
+const templateDataStructure = "{{ .Content }}"
+
+// Default names of the comment tags delimiting generated content
 const (
-	TemplateBeginTag      = "<!-- BEGIN_CHECKOV_DOCS -->"
-	templateDataStructure = "{{ .Content }}"
-	TemplateEndTag        = "<!-- END_CHECKOV_DOCS -->"
+	templateBeginName = "BEGIN_CHECKOV_DOCS"
+	templateEndName   = "END_CHECKOV_DOCS"
 )
 
-// OutputTemplate stores the template used to generate content
-var OutputTemplate = fmt.Sprintf("%s\n\n%s\n\n%s", TemplateBeginTag, templateDataStructure, TemplateEndTag)
+// SectionAttribute is the attribute of the tags naming a section
+const SectionAttribute = "name"
+
+// sectionPattern matches valid section names, which can't break the comment of the tags
+var sectionPattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// markerPattern matches valid tag names, which can't contain blanks or be mistaken for attributes
+var markerPattern = regexp.MustCompile(`^[^\s=]+$`)
+
+// CommentSyntax stores the delimiters of a single-line comment
+type CommentSyntax struct {
+	Prefix string
+	Suffix string
+}
+
+// Comment syntax presets of the tags, named after the type of the output file
+const (
+	CommentSyntaxMarkdown = "markdown"
+	CommentSyntaxHTML     = "html"
+	CommentSyntaxAsciiDoc = "asciidoc"
+	CommentSyntaxRST      = "rst"
+	CommentSyntaxYAML     = "yaml"
+	CommentSyntaxHCL      = "hcl"
+)
+
+// CommentSyntaxes stores the valid values for the `comment-syntax` option
+var CommentSyntaxes = []string{CommentSyntaxMarkdown, CommentSyntaxHTML, CommentSyntaxAsciiDoc, CommentSyntaxRST, CommentSyntaxYAML, CommentSyntaxHCL}
+
+// commentSyntaxes stores the comment syntax of each preset
+var commentSyntaxes = map[string]CommentSyntax{
+	CommentSyntaxMarkdown: {Prefix: "<!-- ", Suffix: " -->"},
+	CommentSyntaxHTML:     {Prefix: "<!-- ", Suffix: " -->"},
+	CommentSyntaxAsciiDoc: {Prefix: "// "},
+	CommentSyntaxRST:      {Prefix: ".. "},
+	CommentSyntaxYAML:     {Prefix: "# "},
+	CommentSyntaxHCL:      {Prefix: "# "},
+}
+
+// formatCommentSyntaxes stores the comment syntax preset of the tags of each output format
+var formatCommentSyntaxes = map[string]string{
+	FormatMarkdown: CommentSyntaxMarkdown,
+	FormatHTML:     CommentSyntaxHTML,
+	FormatAsciiDoc: CommentSyntaxAsciiDoc,
+	FormatRST:      CommentSyntaxRST,
+	FormatList:     CommentSyntaxMarkdown,
+}
+
+// Markers describes the tags delimiting generated content
+type Markers struct {
+	// Begin is the name of the opening tag, `BEGIN_CHECKOV_DOCS` if empty
+	Begin string
+	// End is the name of the closing tag, `END_CHECKOV_DOCS` if empty
+	End string
+	// Syntax is the comment syntax of the tags, the syntax of the output format if nil
+	Syntax *CommentSyntax
+}
+
+// Result types that can be documented, each one maps to a section in checkov results
+const (
+	ResultTypeSkipped = "skipped"
+	ResultTypeFailed  = "failed"
+	ResultTypePassed  = "passed"
+)
+
+// ResultTypes stores the valid values for the `result-type` option
+var ResultTypes = []string{ResultTypeSkipped, ResultTypeFailed, ResultTypePassed}
+
+// StdinInput is the input file name used to read checkov results from stdin
+const StdinInput = "-"
+
+// StdoutOutput is the output file name used to write generated content to stdout
+const StdoutOutput = "-"
+
+// DefaultOutputFile is the output file of sections if none is given
+const DefaultOutputFile = "README.md"
+
+// Output modes, i.e. how generated content is written to an existing output file
+const (
+	// OutputModeInject replaces the section between the tags, which must exist
+	OutputModeInject = "inject"
+	// OutputModeReplace replaces the whole file
+	OutputModeReplace = "replace"
+	// OutputModeAppend replaces the section between the tags, or appends it to the file if the tags don't exist
+	OutputModeAppend = "append"
+	// OutputModePrepend replaces the section between the tags, or prepends it to the file if the tags don't exist
+	OutputModePrepend = "prepend"
+)
+
+// OutputModes stores the valid values for the `output-mode` option
+var OutputModes = []string{OutputModeInject, OutputModeReplace, OutputModeAppend, OutputModePrepend}
+
+// Input formats that can be parsed, `auto` detects the format from the content
+const (
+	InputFormatAuto     = "auto"
+	InputFormatJSON     = "json"
+	InputFormatSARIF    = "sarif"
+	InputFormatJUnitXML = "junitxml"
+)
+
+// InputFormats stores the valid values for the `input-format` option
+var InputFormats = []string{InputFormatAuto, InputFormatJSON, InputFormatSARIF, InputFormatJUnitXML}
+
+// Output formats of generated content
+const (
+	FormatMarkdown = "markdown"
+	FormatHTML     = "html"
+	FormatAsciiDoc = "asciidoc"
+	FormatRST      = "rst"
+	FormatList     = "list"
+	FormatJSON     = "json"
+	FormatYAML     = "yaml"
+	FormatCSV      = "csv"
+)
+
+// Formats stores the valid values for the `format` option
+var Formats = []string{FormatMarkdown, FormatHTML, FormatAsciiDoc, FormatRST, FormatList, FormatJSON, FormatYAML, FormatCSV}
+
+// FileTemplate stores the template used to generate content written as a whole file, without tags
+var FileTemplate = templateDataStructure + "\n"
+
+// Column describes a column of the generated table
+type Column struct {
+	// Field is the name of the check field rendered in the column, e.g. `check_id` or `severity`
+	Field string
+	// Title is the header of the column, defaults to a title derived from the field name
+	Title string
+	// Code renders the values of the column as inline code
+	Code bool
+}
+
+// OutputFileColumns stores the columns of the markdown table for skipped checks
+var OutputFileColumns = []Column{
+	{Field: "file_path", Title: "File"},
+	{Field: "check_id", Title: "Check ID"},
+	{Field: "resource", Title: "Resource ID"},
+	{Field: "suppress_comment", Title: "Reason"},
+}
+
+// FindingsFileColumns stores the columns of the markdown table
+// for failed and passed checks, which don't have a suppress comment
+var FindingsFileColumns = []Column{
+	{Field: "file_path", Title: "File"},
+	{Field: "check_id", Title: "Check ID"},
+	{Field: "resource", Title: "Resource ID"},
+	{Field: "check_name", Title: "Check Name"},
+}
+
+// ExportColumns stores the fields of the checks exported in data formats, e.g. json or csv
+var ExportColumns = []Column{
+	{Field: "file_path"},
+	{Field: "start_line"},
+	{Field: "end_line"},
+	{Field: "check_id"},
+	{Field: "bc_check_id"},
+	{Field: "check_name"},
+	{Field: "check_type"},
+	{Field: "resource"},
+	{Field: "severity"},
+	{Field: "guideline"},
+	{Field: "result"},
+	{Field: "suppress_comment"},
+	{Field: "source"},
+}
+
+// GroupHeadingLevel is the level of the sub-heading rendered above the table of each group of checks
+const GroupHeadingLevel = 3
 
-// OutputFileHeader stores the fields used to generate header in markdown table
-var OutputFileHeader = []string{"File", "Check ID", "Resource ID", "Reason"}
+// GroupTitleEmpty is the sub-heading of the group of checks without a value for the grouped field
+const GroupTitleEmpty = "(none)"
+
+// SummaryHeader stores the fields used to generate header in markdown summary table
+var SummaryHeader = []string{"Passed", "Failed", "Skipped", "Parsing Errors", "Resources", "Checkov Version"}
+
+// GetMarkers returns `markers` where empty values are set to the default tags of `format`
+func GetMarkers(format string, markers Markers) Markers {
+	if markers.Begin == "" {
+		markers.Begin = templateBeginName
+	}
+	if markers.End == "" {
+		markers.End = templateEndName
+	}
+	if markers.Syntax == nil {
+		preset, ok := formatCommentSyntaxes[format]
+		if !ok {
+			preset = CommentSyntaxMarkdown
+		}
+		syntax := commentSyntaxes[preset]
+		markers.Syntax = &syntax
+	}
+	return markers
+}
+
+// Validate returns an error if the names or the comment syntax of the tags are invalid
+func (m Markers) Validate() error {
+	for _, name := range []string{m.Begin, m.End} {
+		if name != "" && !markerPattern.MatchString(name) {
+			return fmt.Errorf("invalid tag name %q, blanks and `=` are not allowed", name)
+		}
+	}
+	if m.Begin != "" && m.Begin == m.End {
+		return fmt.Errorf("opening and closing tags have the same name %q", m.Begin)
+	}
+	if m.Syntax != nil && strings.TrimSpace(m.Syntax.Prefix) == "" {
+		return errors.New("the comment prefix of the tags is required")
+	}
+	return nil
+}
+
+// Tags returns the opening and closing tags of the section for `resultType`, written in the comment syntax of the markers.
+// Skipped checks use the default tag names, other result types have a suffix so
+// that each result type can be documented in its own section of the same file.
+// Named sections have a `name` attribute, e.g. `<!-- BEGIN_CHECKOV_DOCS name=terraform -->`,
+// and `options` are added to the opening tag.
+func (m Markers) Tags(resultType, section string, options ...string) (string, string) {
+	var suffix string
+	if resultType != "" && resultType != ResultTypeSkipped {
+		suffix = "_" + strings.ToUpper(resultType)
+	}
+	if section != "" {
+		suffix += " " + SectionAttribute + "=" + section
+	}
+	var attributes string
+	for _, option := range options {
+		attributes += " " + option
+	}
+	return m.Syntax.Prefix + m.Begin + suffix + attributes + m.Syntax.Suffix,
+		m.Syntax.Prefix + m.End + suffix + m.Syntax.Suffix
+}
+
+// GetTagsTemplate returns the template used to generate content between `beginTag` and `endTag`.
+// Tags are written as string constants, so that tags read from a file can't contain template actions.
+func GetTagsTemplate(beginTag, endTag string) string {
+	return fmt.Sprintf("{{ %q }}\n\n%s\n\n{{ %q }}", beginTag, templateDataStructure, endTag)
+}
+
+// ValidateSection returns an error if `section` isn't a valid section name
+func ValidateSection(section string) error {
+	if section != "" && !sectionPattern.MatchString(section) {
+		return fmt.Errorf("invalid section name %q, only letters, digits, `_`, `.` and `-` are allowed", section)
+	}
+	return nil
+}
+
+// ValidateOutputMode returns an error if `mode` isn't a valid output mode, an empty mode is the append mode
+func ValidateOutputMode(mode string) error {
+	if mode == "" {
+		return nil
+	}
+	for _, m := range OutputModes {
+		if mode == m {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid output mode %q, valid values: %v", mode, OutputModes)
+}
+
+// GetColumns returns the default columns of the markdown table for `resultType`
+func GetColumns(resultType string) []Column {
+	if resultType == "" || resultType == ResultTypeSkipped {
+		return OutputFileColumns
+	}
+	return FindingsFileColumns
+}
+
+// ParseColumns returns the columns defined by `value`, the `columns` setting of the config file.
+// Each column is either a field name or a mapping with `field` and optional `title` and `code` keys.
+func ParseColumns(value interface{}) ([]Column, error) {
+	if value == nil {
+		return nil, nil
+	}
+	items, ok := value.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid columns %v, expected a list", value)
+	}
+
+	columns := make([]Column, len(items))
+	for i, item := range items {
+		switch item := item.(type) {
+		case string:
+			columns[i] = Column{Field: item}
+		case map[string]interface{}:
+			field, _ := item["field"].(string)
+			title, _ := item["title"].(string)
+			code, _ := item["code"].(bool)
+			if field == "" {
+				return nil, fmt.Errorf("invalid column %d: field is required", i+1)
+			}
+			columns[i] = Column{Field: field, Title: title, Code: code}
+		default:
+			return nil, fmt.Errorf("invalid column %d: expected a field name or a mapping, got %v", i+1, item)
+		}
+	}
+
+	return columns, nil
+}
+
+// ParseCommentSyntax returns the comment syntax represented by `value`, either the name of a preset,
+// see CommentSyntaxes, or a mapping with a `prefix` and a `suffix`. It returns nil if `value` is empty.
+func ParseCommentSyntax(value interface{}) (*CommentSyntax, error) {
+	switch value := value.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		if value == "" {
+			return nil, nil
+		}
+		syntax, ok := commentSyntaxes[strings.ToLower(value)]
+		if !ok {
+			return nil, fmt.Errorf("invalid comment syntax %q, valid values: %v", value, CommentSyntaxes)
+		}
+		return &syntax, nil
+	case map[string]interface{}:
+		prefix, _ := value["prefix"].(string)
+		suffix, _ := value["suffix"].(string)
+		if strings.TrimSpace(prefix) == "" {
+			return nil, errors.New("invalid comment syntax: prefix is required")
+		}
+		return &CommentSyntax{Prefix: prefix, Suffix: suffix}, nil
+	default:
+		return nil, fmt.Errorf("invalid comment syntax %v, expected a preset name or a mapping", value)
+	}
+}
 
 // GetMarkdownHeader returns the markdown-formatted header
 // revive:disable:unhandled-error ignore error in `WriteString`
@@ -46,8 +367,8 @@ func GetMarkdownHeader() string {
 	var sb strings.Builder
 
 	sb.WriteString("|")
-	for _, val := range OutputFileHeader {
-		sb.WriteString(fmt.Sprintf(" %s |", val))
+	for _, column := range OutputFileColumns {
+		sb.WriteString(fmt.Sprintf(" %s |", column.Title))
 	}
 
 	return sb.String()
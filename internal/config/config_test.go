@@ -0,0 +1,168 @@
+/*
+Copyright © 2023 The checkov-docs Authors
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseColumns(t *testing.T) {
+	assert := assert.New(t)
+
+	// Test parsing field names and mappings
+	columns, err := ParseColumns([]interface{}{
+		"check_id",
+		map[string]interface{}{"field": "severity"},
+		map[string]interface{}{"field": "line", "title": "Lines", "code": true},
+	})
+	assert.Nil(err, "unexpected error parsing columns", err)
+	assert.Equal([]Column{{Field: "check_id"}, {Field: "severity"}, {Field: "line", Title: "Lines", Code: true}}, columns)
+
+	// Test parsing unset columns
+	columns, err = ParseColumns(nil)
+	assert.Nil(err, "unexpected error parsing columns", err)
+	assert.Nil(columns)
+
+	// Test parsing invalid columns
+	_, err = ParseColumns("check_id")
+	assert.NotNil(err, "expected an error for columns that aren't a list, but got no error")
+	_, err = ParseColumns([]interface{}{map[string]interface{}{"title": "Severity"}})
+	assert.NotNil(err, "expected an error for a column without field, but got no error")
+	_, err = ParseColumns([]interface{}{42})
+	assert.NotNil(err, "expected an error for an invalid column, but got no error")
+}
+
+func TestGetColumns(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(OutputFileColumns, GetColumns(""))
+	assert.Equal(OutputFileColumns, GetColumns(ResultTypeSkipped))
+	assert.Equal(FindingsFileColumns, GetColumns(ResultTypeFailed))
+}
+
+func TestValidateOutputMode(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, mode := range append([]string{""}, OutputModes...) {
+		assert.Nil(ValidateOutputMode(mode), "unexpected error for output mode %q", mode)
+	}
+	for _, mode := range []string{"merge", "INJECT"} {
+		assert.NotNil(ValidateOutputMode(mode), "expected an error for output mode %q", mode)
+	}
+}
+
+func TestMarkers_Tags(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		format     string
+		resultType string
+		section    string
+		beginTag   string
+		endTag     string
+	}{
+		{FormatMarkdown, "", "", "<!-- BEGIN_CHECKOV_DOCS -->", "<!-- END_CHECKOV_DOCS -->"},
+		{FormatHTML, ResultTypeSkipped, "", "<!-- BEGIN_CHECKOV_DOCS -->", "<!-- END_CHECKOV_DOCS -->"},
+		{FormatMarkdown, ResultTypeFailed, "", "<!-- BEGIN_CHECKOV_DOCS_FAILED -->", "<!-- END_CHECKOV_DOCS_FAILED -->"},
+		{FormatMarkdown, "", "terraform", "<!-- BEGIN_CHECKOV_DOCS name=terraform -->", "<!-- END_CHECKOV_DOCS name=terraform -->"},
+		{FormatAsciiDoc, "", "", "// BEGIN_CHECKOV_DOCS", "// END_CHECKOV_DOCS"},
+		{FormatRST, ResultTypePassed, "k8s", ".. BEGIN_CHECKOV_DOCS_PASSED name=k8s", ".. END_CHECKOV_DOCS_PASSED name=k8s"},
+		{"", "", "", "<!-- BEGIN_CHECKOV_DOCS -->", "<!-- END_CHECKOV_DOCS -->"},
+	}
+
+	// Test the default tags of each format
+	for _, tt := range tests {
+		beginTag, endTag := GetMarkers(tt.format, Markers{}).Tags(tt.resultType, tt.section)
+		assert.Equal(tt.beginTag, beginTag, "unexpected opening tag for %q %q %q", tt.format, tt.resultType, tt.section)
+		assert.Equal(tt.endTag, endTag, "unexpected closing tag for %q %q %q", tt.format, tt.resultType, tt.section)
+	}
+	assert.Equal(`{{ ".. BEGIN_CHECKOV_DOCS" }}`+"\n\n{{ .Content }}\n\n"+`{{ ".. END_CHECKOV_DOCS" }}`, GetTagsTemplate(GetMarkers(FormatRST, Markers{}).Tags("", "")))
+
+	// Test custom tags
+	markers := GetMarkers(FormatMarkdown, Markers{Begin: "BEGIN_SECURITY", Syntax: &CommentSyntax{Prefix: "# "}})
+	beginTag, endTag := markers.Tags(ResultTypeFailed, "terraform", "format=list", "summary=true")
+	assert.Equal("# BEGIN_SECURITY_FAILED name=terraform format=list summary=true", beginTag)
+	assert.Equal("# END_CHECKOV_DOCS_FAILED name=terraform", endTag)
+
+	markers = GetMarkers(FormatAsciiDoc, Markers{End: "END_SECURITY"})
+	beginTag, endTag = markers.Tags("", "")
+	assert.Equal("// BEGIN_CHECKOV_DOCS", beginTag)
+	assert.Equal("// END_SECURITY", endTag)
+}
+
+func TestMarkers_Validate(t *testing.T) {
+	assert := assert.New(t)
+
+	valid := []Markers{
+		{},
+		{Begin: "BEGIN_SECURITY", End: "END_SECURITY"},
+		{Syntax: &CommentSyntax{Prefix: "{# ", Suffix: " #}"}},
+	}
+	for _, markers := range valid {
+		assert.Nil(markers.Validate(), "unexpected error for markers %+v", markers)
+	}
+
+	invalid := []Markers{
+		{Begin: "BEGIN DOCS"},
+		{End: "name=x"},
+		{Begin: "DOCS", End: "DOCS"},
+		{Syntax: &CommentSyntax{Prefix: " ", Suffix: " -->"}},
+	}
+	for _, markers := range invalid {
+		assert.NotNil(markers.Validate(), "expected an error for markers %+v", markers)
+	}
+}
+
+func TestParseCommentSyntax(t *testing.T) {
+	assert := assert.New(t)
+
+	syntax, err := ParseCommentSyntax(nil)
+	assert.Nil(err, "unexpected error parsing empty comment syntax", err)
+	assert.Nil(syntax)
+
+	syntax, err = ParseCommentSyntax("HCL")
+	assert.Nil(err, "unexpected error parsing comment syntax preset", err)
+	assert.Equal(&CommentSyntax{Prefix: "# "}, syntax)
+
+	syntax, err = ParseCommentSyntax(map[string]interface{}{"prefix": "{/* ", "suffix": " */}"})
+	assert.Nil(err, "unexpected error parsing comment syntax mapping", err)
+	assert.Equal(&CommentSyntax{Prefix: "{/* ", Suffix: " */}"}, syntax)
+
+	for _, value := range []interface{}{"latex", map[string]interface{}{"suffix": " -->"}, 42} {
+		_, err = ParseCommentSyntax(value)
+		assert.NotNil(err, "expected an error for comment syntax %v", value)
+	}
+}
+
+func TestValidateSection(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, section := range []string{"", "terraform", "k8s-prod_1.2"} {
+		assert.Nil(ValidateSection(section), "unexpected error for section %q", section)
+	}
+	for _, section := range []string{"two words", "x-->", "{{ .Content }}", "a\nb"} {
+		assert.NotNil(ValidateSection(section), "expected an error for section %q", section)
+	}
+}
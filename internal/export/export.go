@@ -0,0 +1,214 @@
+/*
+Copyright © 2023 The checkov-docs Authors
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/checkov-docs/checkov-docs/internal/logger"
+	"github.com/checkov-docs/checkov-docs/internal/models"
+)
+
+// formulaStarts stores the characters starting a formula in spreadsheet applications
+const formulaStarts = "=+-@\t\r"
+
+// lineFields stores the fields exported as numbers, or null if the line is unknown
+var lineFields = map[string]func(*models.Check) int{
+	"start_line": (*models.Check).StartLine,
+	"end_line":   (*models.Check).EndLine,
+}
+
+// record stores the values of the fields of a check, encoded in the order of the fields.
+// Values are strings, or integers and nil for line numbers, see lineFields.
+type record struct {
+	fields []string
+	values []interface{}
+}
+
+// MarshalJSON implements the json.Marshaler interface
+func (r record) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, field := range r.fields {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		if err := encodeJSON(&buf, field, ""); err != nil {
+			return nil, err
+		}
+		buf.WriteByte(':')
+		if err := encodeJSON(&buf, r.values[i], ""); err != nil {
+			return nil, err
+		}
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// encodeJSON writes `v` to `buf` as JSON indented with `indent`, HTML characters aren't escaped
+func encodeJSON(buf *bytes.Buffer, v interface{}, indent string) error {
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", indent)
+	if err := encoder.Encode(v); err != nil {
+		return err
+	}
+	// drop the new line written after each value
+	buf.Truncate(buf.Len() - 1)
+	return nil
+}
+
+// newRecords returns a record with the values of `fields` for each of `checks`
+func newRecords(fields []string, checks []*models.Check) []record {
+	records := make([]record, len(checks))
+	for i, check := range checks {
+		values := make([]interface{}, len(fields))
+		for j, field := range fields {
+			values[j] = fieldValue(check, field)
+		}
+		records[i] = record{fields: fields, values: values}
+	}
+	return records
+}
+
+// fieldValue returns the value of `field` of `check`, line numbers are integers or nil if unknown
+func fieldValue(check *models.Check, field string) interface{} {
+	getLine, ok := lineFields[field]
+	if !ok {
+		return check.Field(field)
+	}
+	if line := getLine(check); line != 0 {
+		return line
+	}
+	return nil
+}
+
+// formatValue returns the string representation of a value of a record, empty if it's nil
+func formatValue(value interface{}) string {
+	switch value := value.(type) {
+	case nil:
+		return ""
+	case int:
+		return strconv.Itoa(value)
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// WriteJSON returns a JSON array with an object for each of `checks`,
+// mapping the names of `fields` to their values
+func WriteJSON(fields []string, checks []*models.Check, logger *logger.Logger) (string, error) {
+	logger.Info("export checks as json")
+
+	var buf bytes.Buffer
+	if err := encodeJSON(&buf, newRecords(fields, checks), "  "); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// WriteYAML returns a YAML sequence with a mapping for each of `checks`,
+// mapping the names of `fields` to their values
+func WriteYAML(fields []string, checks []*models.Check, logger *logger.Logger) (string, error) {
+	logger.Info("export checks as yaml")
+
+	doc := &yaml.Node{Kind: yaml.SequenceNode}
+	for _, r := range newRecords(fields, checks) {
+		mapping := &yaml.Node{Kind: yaml.MappingNode}
+		for i, field := range r.fields {
+			mapping.Content = append(mapping.Content,
+				&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: field},
+				yamlValue(r.values[i]),
+			)
+		}
+		doc.Content = append(doc.Content, mapping)
+	}
+	if len(doc.Content) == 0 {
+		// an empty sequence is written as `[]`
+		doc.Style = yaml.FlowStyle
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(doc); err != nil {
+		return "", err
+	}
+	if err := encoder.Close(); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// yamlValue returns the YAML node of a value of a record, other values than line numbers are always strings
+func yamlValue(value interface{}) *yaml.Node {
+	switch value.(type) {
+	case nil:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}
+	case int:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: formatValue(value)}
+	default:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: formatValue(value)}
+	}
+}
+
+// WriteCSV returns a CSV table with the names of `fields` as header and a record for each of `checks`.
+// Values starting like a formula are prefixed with a quote so that spreadsheet applications render them as text.
+func WriteCSV(fields []string, checks []*models.Check, logger *logger.Logger) (string, error) {
+	logger.Info("export checks as csv")
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(fields); err != nil {
+		return "", err
+	}
+	for _, r := range newRecords(fields, checks) {
+		values := make([]string, len(r.values))
+		for i, value := range r.values {
+			values[i] = escapeFormula(formatValue(value))
+		}
+		if err := w.Write(values); err != nil {
+			return "", err
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// escapeFormula returns `value` prefixed with a quote if it starts with a formula character
+func escapeFormula(value string) string {
+	if value != "" && strings.ContainsRune(formulaStarts, rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
@@ -0,0 +1,112 @@
+/*
+Copyright © 2023 The checkov-docs Authors
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package export
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/checkov-docs/checkov-docs/internal/logger"
+	"github.com/checkov-docs/checkov-docs/internal/models"
+)
+
+var testChecks = []*models.Check{
+	{CheckID: "CKV_AWS_18", FilePath: "/s3.tf", FileLineRange: []int{1, 12}, CheckResult: &models.CheckResult{SuppressComment: "=HYPERLINK(\"https://example.com\")"}},
+	{CheckID: "CKV_AWS_21", FilePath: "/s3.tf", CheckResult: &models.CheckResult{SuppressComment: "<not \"needed\">"}},
+}
+
+func TestWriteJSON(t *testing.T) {
+	assert := assert.New(t)
+	logger := logger.NewMockLogger(&bytes.Buffer{})
+
+	// Test exporting checks, keys are written in the order of the fields and line numbers as numbers
+	output, err := WriteJSON([]string{"check_id", "start_line", "suppress_comment"}, testChecks, logger)
+	assert.Nil(err, "unexpected error exporting checks", err)
+	assert.Equal(`[
+  {
+    "check_id": "CKV_AWS_18",
+    "start_line": 1,
+    "suppress_comment": "=HYPERLINK(\"https://example.com\")"
+  },
+  {
+    "check_id": "CKV_AWS_21",
+    "start_line": null,
+    "suppress_comment": "<not \"needed\">"
+  }
+]`, output)
+
+	// Test exporting no checks
+	output, err = WriteJSON([]string{"check_id"}, nil, logger)
+	assert.Nil(err, "unexpected error exporting checks", err)
+	assert.Equal("[]", output)
+}
+
+func TestWriteYAML(t *testing.T) {
+	assert := assert.New(t)
+	logger := logger.NewMockLogger(&bytes.Buffer{})
+
+	// Test exporting checks, values are strings except line numbers
+	output, err := WriteYAML([]string{"check_id", "start_line", "file_path"}, testChecks, logger)
+	assert.Nil(err, "unexpected error exporting checks", err)
+	assert.Equal(`- check_id: CKV_AWS_18
+  start_line: 1
+  file_path: /s3.tf
+- check_id: CKV_AWS_21
+  start_line: null
+  file_path: /s3.tf`, output)
+
+	// Test exporting no checks
+	output, err = WriteYAML([]string{"check_id"}, nil, logger)
+	assert.Nil(err, "unexpected error exporting checks", err)
+	assert.Equal("[]", output)
+}
+
+func TestWriteCSV(t *testing.T) {
+	assert := assert.New(t)
+	logger := logger.NewMockLogger(&bytes.Buffer{})
+
+	// Test exporting checks, formulas are rendered as text
+	output, err := WriteCSV([]string{"check_id", "file_line_range", "end_line", "suppress_comment"}, testChecks, logger)
+	assert.Nil(err, "unexpected error exporting checks", err)
+	assert.Equal(`check_id,file_line_range,end_line,suppress_comment
+CKV_AWS_18,1-12,12,"'=HYPERLINK(""https://example.com"")"
+CKV_AWS_21,,,"<not ""needed"">"`, output)
+
+	// Test exporting no checks
+	output, err = WriteCSV([]string{"check_id"}, nil, logger)
+	assert.Nil(err, "unexpected error exporting checks", err)
+	assert.Equal("check_id", output)
+}
+
+func TestEscapeFormula(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, value := range []string{"=1+1", "+1", "-1", "@SUM(A1)", "\tx", "\rx"} {
+		assert.Equal("'"+value, escapeFormula(value))
+	}
+	for _, value := range []string{"", "a=1", "CKV_AWS_18"} {
+		assert.Equal(value, escapeFormula(value))
+	}
+}
@@ -0,0 +1,163 @@
+/*
+Copyright © 2023 The checkov-docs Authors
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package filewriter
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/checkov-docs/checkov-docs/internal/logger"
+)
+
+// BackupSuffix is the suffix of the backup of the output file
+const BackupSuffix = ".bak"
+
+// defaultPerm is the permission of new files, before the umask is applied
+const defaultPerm fs.FileMode = 0666
+
+// writeFile atomically writes `data` to the file at `path`: data is written to a temporary file in the same directory,
+// which is renamed to the file, so that the file is either unchanged or fully written.
+// Permissions and ownership of an existing file are preserved, new files are created with the permissions of the umask.
+// If `path` is a symbolic link, its target is written and the link is kept if `followSymlinks` is set,
+// an error is returned otherwise so that a link can't redirect the output to an unexpected file.
+// If `backup` is set, the existing file is copied to a file with the BackupSuffix first.
+func writeFile(path string, data []byte, backup bool, followSymlinks bool, logger *logger.Logger) error {
+	target, err := resolveLink(path, followSymlinks)
+	if err != nil {
+		return err
+	}
+	if target != path {
+		logger.Debug("write target of symbolic link", "link", path, "target", target)
+	}
+
+	info, err := os.Stat(target)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	if info != nil && !info.Mode().IsRegular() {
+		return fmt.Errorf("output file %s is not a regular file", target)
+	}
+
+	if info != nil && backup {
+		if err = backupFile(target, info, logger); err != nil {
+			return fmt.Errorf("failed to back up output file: %w", err)
+		}
+	}
+
+	return replaceFile(target, data, info, logger)
+}
+
+// backupFile copies the file at `path` to a file with the BackupSuffix, with the same permissions and ownership
+func backupFile(path string, info fs.FileInfo, logger *logger.Logger) error {
+	existing, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return err
+	}
+	if err = replaceFile(path+BackupSuffix, existing, info, logger); err != nil {
+		return err
+	}
+	logger.Info("output file backed up", "path", path+BackupSuffix)
+	return nil
+}
+
+// resolveLink returns the path of the file `path` links to if it's a symbolic link, or `path` otherwise.
+// An error is returned for symbolic links unless `follow` is set.
+func resolveLink(path string, follow bool) (string, error) {
+	info, err := os.Lstat(path)
+	if err != nil || info.Mode()&fs.ModeSymlink == 0 {
+		return path, nil
+	}
+	if !follow {
+		return "", fmt.Errorf("output file %s is a symbolic link, writing through it must be enabled explicitly", path)
+	}
+	target, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve symbolic link %s: %w", path, err)
+	}
+	return target, nil
+}
+
+// replaceFile writes `data` to a temporary file renamed to `path`, with the permissions and ownership of `info`
+// if it isn't nil. The temporary file is removed if it can't be renamed.
+func replaceFile(path string, data []byte, info fs.FileInfo, logger *logger.Logger) (err error) {
+	perm := defaultPerm
+	if info != nil {
+		perm = info.Mode().Perm()
+	}
+	tmp, err := createTemp(path, perm)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = os.Remove(tmp.Name())
+		}
+	}()
+
+	if _, err = tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err = tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+
+	if info != nil {
+		// the umask applies to the temporary file, the permissions of the existing file are restored
+		if err = os.Chmod(tmp.Name(), perm); err != nil {
+			return err
+		}
+		if chownErr := chown(tmp.Name(), info); chownErr != nil {
+			logger.Warn("failed to preserve ownership of output file", chownErr.Error())
+		}
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+// createTemp creates a new temporary file next to `path` with `perm` permissions, before the umask is applied.
+// Unlike os.CreateTemp, which creates files only readable by their owner, new files get the usual permissions.
+func createTemp(path string, perm fs.FileMode) (*os.File, error) {
+	dir, base := filepath.Split(path)
+	suffix := make([]byte, 8)
+	for i := 0; i < 100; i++ {
+		if _, err := rand.Read(suffix); err != nil {
+			return nil, err
+		}
+		name := filepath.Join(dir, "."+base+"."+hex.EncodeToString(suffix)+".tmp")
+		f, err := os.OpenFile(filepath.Clean(name), os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+		if !errors.Is(err, fs.ErrExist) {
+			return f, err
+		}
+	}
+	return nil, fmt.Errorf("failed to create a temporary file for %s", path)
+}
@@ -26,11 +26,13 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
 	"text/template"
 
+	"github.com/checkov-docs/checkov-docs/internal/config"
 	"github.com/checkov-docs/checkov-docs/internal/logger"
 )
 
@@ -38,63 +40,238 @@ import (
 //
 // Step 1. Validate filepath
 // Step 2. Render template
-// Step 3. Write generated content to output file, i.e. append or inject
+// Step 3. Write generated content to output file, i.e. inject, append, prepend or replace, see Mode
+// Step 4. Replace the output file atomically, after an optional backup
+//
+// Content returns the result of step 3 without writing it, e.g. to check if the file is up to date.
+// If both tags are empty, generated content replaces the whole file.
 type FileWriter struct {
 	Filepath   string
 	Template   string
 	OpeningTag string
 	ClosingTag string
-	Logger     *logger.Logger
+	// Mode is the output mode, see config.OutputModes, the append mode if empty
+	Mode string
+	// Backup copies the existing file to a file with the BackupSuffix before it is modified
+	Backup bool
+	// FollowSymlinks writes the target of the file if it's a symbolic link, which is an error otherwise
+	FollowSymlinks bool
+	// LineTags is set if the tags are line comments, e.g. `// BEGIN_CHECKOV_DOCS` in asciidoc, which only
+	// delimit the section when they are alone on their line. Other tags, e.g. HTML comments, can be inside a line.
+	LineTags bool
+	Logger   *logger.Logger
 }
 
-// Write content to file
+// Write content to file, atomically so that the file is never partially written, see writeFile
 func (fw *FileWriter) Write(p []byte) (int, error) {
 	fw.Logger.Info("write content to output file")
 
-	buf, err := fw.render(p)
+	current, updated, err := fw.Content(p)
 	if err != nil {
 		return 0, err
 	}
 
+	return 0, writeFile(fw.Filepath, []byte(updated), fw.Backup && current != updated, fw.FollowSymlinks, fw.Logger)
+}
+
+// Content returns the current content of the file and the content it would have once `p` is written,
+// without writing it. The current content is empty if the file doesn't exist.
+func (fw *FileWriter) Content(p []byte) (string, string, error) {
+	buf, err := fw.render(p)
+	if err != nil {
+		return "", "", err
+	}
+
+	wholeFile := fw.OpeningTag == "" && fw.ClosingTag == ""
 	existingContent, err := os.ReadFile(filepath.Clean(fw.Filepath))
 	if err != nil {
-		// if file doesn't exist, create it and write generated output
-		return 0, os.WriteFile(fw.Filepath, buf.Bytes(), 0644)
+		if fw.Mode == config.OutputModeInject && !wholeFile {
+			return "", "", fmt.Errorf("failed to read output file in inject mode: %w", err)
+		}
+		// if file doesn't exist, generated output is the whole file
+		return "", buf.String(), nil
 	}
 
-	if len(existingContent) == 0 {
-		// if file exists but it's empty, write generated output
-		return 0, os.WriteFile(fw.Filepath, buf.Bytes(), 0644)
+	if wholeFile || fw.Mode == config.OutputModeReplace {
+		// without tags or in replace mode, generated output is the whole file
+		return string(existingContent), buf.String(), nil
 	}
 
-	return fw.write(string(existingContent), buf.String())
+	if len(existingContent) == 0 && fw.Mode != config.OutputModeInject {
+		// if file exists but it's empty, generated output is the whole file
+		return "", buf.String(), nil
+	}
+
+	merged, err := fw.merge(string(existingContent), buf.String())
+	if err != nil {
+		return "", "", err
+	}
+	return string(existingContent), merged, nil
 }
 
-// write appends or injects generated output to a file
-func (fw *FileWriter) write(content, generated string) (int, error) {
+// merge returns generated output injected in `content`, or appended or prepended to it if there are no tags
+func (fw *FileWriter) merge(content, generated string) (string, error) {
 	// Find the position of the opening and closing tags
-	openingIndex := strings.Index(content, fw.OpeningTag)
-	closingIndex := strings.Index(content, fw.ClosingTag)
+	openingIndex := fw.indexTag(content, fw.OpeningTag, 0)
+	closingIndex := fw.indexTag(content, fw.ClosingTag, 0)
+	if openingIndex != -1 {
+		closingIndex = fw.indexTag(content, fw.ClosingTag, openingIndex+len(fw.OpeningTag))
+	}
 
-	// if no tags found, simply append generated output to existing content
+	// if no tags found, add generated output to existing content unless it must be injected
 	if openingIndex == -1 && closingIndex == -1 {
-		return 0, os.WriteFile(fw.Filepath, []byte(content+"\n"+generated), 0644)
+		if fw.LineTags && (strings.Contains(content, fw.OpeningTag) || strings.Contains(content, fw.ClosingTag)) {
+			// don't add a second section if the tags were moved inside a line by mistake
+			return "", fmt.Errorf("comment tags %q and %q must be alone on their line", fw.OpeningTag, fw.ClosingTag)
+		}
+		switch fw.Mode {
+		case config.OutputModeInject:
+			return "", fmt.Errorf("comment tags %q and %q are not found in inject mode", fw.OpeningTag, fw.ClosingTag)
+		case config.OutputModePrepend:
+			return generated + "\n\n" + content, nil
+		default:
+			return content + "\n" + generated, nil
+		}
 	}
 
 	if openingIndex == -1 {
-		return 0, errors.New("opening comment tag is not found")
+		return "", errors.New("opening comment tag is not found")
 	}
 
 	if closingIndex == -1 {
-		return 0, errors.New("closing comment tag is not found")
+		return "", errors.New("closing comment tag is not found")
 	}
 
 	// if both tags found, merge generated output with existing content
 	// note that both tags in the existing content are omitted
 	// because the generated output already includes them
-	mergedContent := fmt.Sprintf("%s%s%s", content[:openingIndex], generated, content[closingIndex+len(fw.ClosingTag):])
+	return fmt.Sprintf("%s%s%s", content[:openingIndex], generated, content[closingIndex+len(fw.ClosingTag):]), nil
+}
+
+// Marker is an opening tag found in the file, which can have options in addition to the attributes of OpeningTag,
+// e.g. `<!-- BEGIN_CHECKOV_DOCS format=list group-by=file -->`
+type Marker struct {
+	// Tag is the opening tag as written in the file, without surrounding blanks
+	Tag string
+	// Options are the `key=value` attributes of the tag which aren't attributes of OpeningTag, in their order
+	Options []Option
+}
+
+// Option is a `key=value` attribute of a tag
+type Option struct {
+	Key   string
+	Value string
+}
+
+// FindMarker returns the first line of the file which is the opening tag, with the same comment delimiters,
+// name and section, and optional `key=value` options. It returns nil if the file or the tag doesn't exist.
+func (fw *FileWriter) FindMarker() (*Marker, error) {
+	content, err := os.ReadFile(filepath.Clean(fw.Filepath))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
 
-	return 0, os.WriteFile(fw.Filepath, []byte(mergedContent), 0644)
+	tag := strings.Fields(fw.OpeningTag)
+	for _, line := range strings.Split(string(content), "\n") {
+		if options, ok := matchTag(tag, strings.Fields(line)); ok {
+			return &Marker{Tag: strings.TrimSpace(line), Options: options}, nil
+		}
+	}
+	return nil, nil
+}
+
+// matchTag returns the options of the line with `words` if it is the tag with `tagWords` with additional options.
+// Words of the tag which aren't attributes must be the same and in the same order, attributes of the tag,
+// e.g. `name=terraform`, must have the same value and a line without section can't have a section name.
+func matchTag(tagWords, words []string) ([]Option, bool) {
+	tagText, tagOptions := splitAttributes(tagWords)
+	text, attributes := splitAttributes(words)
+	tagAttributes := make(map[string]string, len(tagOptions))
+	for _, attribute := range tagOptions {
+		tagAttributes[attribute.Key] = attribute.Value
+	}
+	if len(text) != len(tagText) {
+		return nil, false
+	}
+	for i := range text {
+		if text[i] != tagText[i] {
+			return nil, false
+		}
+	}
+
+	options := []Option{}
+	matched := make(map[string]bool, len(tagAttributes))
+	for _, attribute := range attributes {
+		value, ok := tagAttributes[attribute.Key]
+		switch {
+		case ok && value == attribute.Value:
+			matched[attribute.Key] = true
+		case ok, attribute.Key == config.SectionAttribute:
+			return nil, false
+		default:
+			options = append(options, attribute)
+		}
+	}
+	return options, len(matched) == len(tagAttributes)
+}
+
+// splitAttributes returns the words of a tag which aren't `key=value` attributes, and the attributes.
+// The first word is never an attribute, it is the start of the comment.
+func splitAttributes(words []string) ([]string, []Option) {
+	var text []string
+	var attributes []Option
+	for i, word := range words {
+		key, value, found := strings.Cut(word, "=")
+		if i == 0 || !found || key == "" {
+			text = append(text, word)
+			continue
+		}
+		attributes = append(attributes, Option{Key: key, Value: value})
+	}
+	return text, attributes
+}
+
+// indexTag returns the index of the first occurrence of `tag` in `content` from index `from`, or -1 if there is none.
+// Line tags must be alone on their line, ignoring surrounding blanks: occurrences inside a line,
+// e.g. in a value of a generated table, are not tags.
+func (fw *FileWriter) indexTag(content, tag string, from int) int {
+	if !fw.LineTags {
+		if i := strings.Index(content[from:], tag); i != -1 {
+			return from + i
+		}
+		return -1
+	}
+	for from <= len(content) {
+		i := strings.Index(content[from:], tag)
+		if i == -1 {
+			return -1
+		}
+		i += from
+		if isLineStart(content, i) && isLineEnd(content, i+len(tag)) {
+			return i
+		}
+		from = i + 1
+	}
+	return -1
+}
+
+// isLineStart returns true if only blanks precede index `i` on its line
+func isLineStart(content string, i int) bool {
+	for i > 0 && (content[i-1] == ' ' || content[i-1] == '\t') {
+		i--
+	}
+	return i == 0 || content[i-1] == '\n'
+}
+
+// isLineEnd returns true if only blanks follow index `i` on its line
+func isLineEnd(content string, i int) bool {
+	for i < len(content) && (content[i] == ' ' || content[i] == '\t' || content[i] == '\r') {
+		i++
+	}
+	return i == len(content) || content[i] == '\n'
 }
 
 // render parses and applies the template to generate output content
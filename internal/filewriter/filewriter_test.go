@@ -25,6 +25,7 @@ package filewriter
 import (
 	"io"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -33,6 +34,12 @@ import (
 	"github.com/checkov-docs/checkov-docs/internal/logger"
 )
 
+// Default tags of markdown sections
+var testBeginTag, testEndTag = config.GetMarkers(config.FormatMarkdown, config.Markers{}).Tags("", "")
+
+// testTemplate renders content between the default tags of markdown sections
+var testTemplate = config.GetTagsTemplate(testBeginTag, testEndTag)
+
 var testLogger = []logger.Logger{*logger.NewLogger("test-checkov-docs", "INFO")}
 
 func TestFileWriter_Write(t *testing.T) {
@@ -43,9 +50,9 @@ func TestFileWriter_Write(t *testing.T) {
 
 	fw := &FileWriter{
 		Filepath:   filePath,
-		Template:   config.OutputTemplate,
-		OpeningTag: config.TemplateBeginTag,
-		ClosingTag: config.TemplateEndTag,
+		Template:   testTemplate,
+		OpeningTag: testBeginTag,
+		ClosingTag: testEndTag,
 		Logger:     &testLogger[0],
 	}
 
@@ -92,7 +99,7 @@ func TestFileWriter_Write(t *testing.T) {
 }
 
 func getExpected(input string) string {
-	return config.TemplateBeginTag + "\n\n" + input + "\n\n" + config.TemplateEndTag
+	return testBeginTag + "\n\n" + input + "\n\n" + testEndTag
 }
 
 func TestFileWriter_Write_Error(t *testing.T) {
@@ -101,9 +108,9 @@ func TestFileWriter_Write_Error(t *testing.T) {
 	// Prepare test data
 	fw := &FileWriter{
 		Filepath:   "nonexistentpath/output.txt",
-		Template:   config.OutputTemplate,
-		OpeningTag: config.TemplateBeginTag,
-		ClosingTag: config.TemplateEndTag,
+		Template:   testTemplate,
+		OpeningTag: testBeginTag,
+		ClosingTag: testEndTag,
 		Logger:     &testLogger[0],
 	}
 
@@ -113,13 +120,349 @@ func TestFileWriter_Write_Error(t *testing.T) {
 	assert.NotNil(err, "expected an error when writing to a non-existent directory, but got no error")
 }
 
+func TestFileWriter_Write_WholeFile(t *testing.T) {
+	assert := assert.New(t)
+
+	// Prepare test data
+	filePath := "output.html"
+	err := os.WriteFile(filePath, []byte("<html>old page</html>\n"+testBeginTag+"\n"), 0644)
+	assert.Nil(err, "unexpected error while creating file", err)
+	defer os.Remove(filePath)
+
+	fw := &FileWriter{
+		Filepath: filePath,
+		Template: config.FileTemplate,
+		Logger:   &testLogger[0],
+	}
+
+	// Test replacing the whole file when no tags are set
+	_, err = io.WriteString(fw, "<html>new page</html>")
+	assert.Nil(err, "unexpected error while writing whole file", err)
+
+	// Verify file content
+	actual, err := os.ReadFile(filePath)
+	assert.Nil(err, "unexpected error while reading file", err)
+	assert.Equal("<html>new page</html>\n", string(actual))
+}
+
+func TestFileWriter_Write_Atomic(t *testing.T) {
+	assert := assert.New(t)
+
+	// Prepare test data
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "README.md")
+	existing := "# Title\n\n" + getExpected("old") + "\n"
+	err := os.WriteFile(filePath, []byte(existing), 0600)
+	assert.Nil(err, "unexpected error while creating file", err)
+	err = os.Chmod(filePath, 0640)
+	assert.Nil(err, "unexpected error while setting file permissions", err)
+
+	fw := &FileWriter{
+		Filepath:   filePath,
+		Template:   testTemplate,
+		OpeningTag: testBeginTag,
+		ClosingTag: testEndTag,
+		Backup:     true,
+		Logger:     &testLogger[0],
+	}
+
+	// Test writing an existing file with a backup
+	_, err = io.WriteString(fw, "new")
+	assert.Nil(err, "unexpected error while writing file", err)
+
+	// Verify file content and permissions, the backup and that no temporary file is left
+	actual, err := os.ReadFile(filePath)
+	assert.Nil(err, "unexpected error while reading file", err)
+	assert.Equal("# Title\n\n"+getExpected("new")+"\n", string(actual))
+	info, err := os.Stat(filePath)
+	assert.Nil(err, "unexpected error while reading file info", err)
+	assert.Equal(os.FileMode(0640), info.Mode().Perm())
+	backup, err := os.ReadFile(filePath + BackupSuffix)
+	assert.Nil(err, "unexpected error while reading backup file", err)
+	assert.Equal(existing, string(backup))
+	entries, err := os.ReadDir(dir)
+	assert.Nil(err, "unexpected error while reading directory", err)
+	assert.Len(entries, 2, "unexpected files in output directory: %v", entries)
+
+	// Test the backup isn't replaced if the file is unchanged
+	_, err = io.WriteString(fw, "new")
+	assert.Nil(err, "unexpected error while writing file", err)
+	backup, err = os.ReadFile(filePath + BackupSuffix)
+	assert.Nil(err, "unexpected error while reading backup file", err)
+	assert.Equal(existing, string(backup))
+
+	// Test writing through a symbolic link is refused by default
+	linkPath := filepath.Join(dir, "link.md")
+	err = os.Symlink("README.md", linkPath)
+	assert.Nil(err, "unexpected error while creating symbolic link", err)
+	fw.Filepath, fw.Backup = linkPath, false
+	_, err = io.WriteString(fw, "linked")
+	assert.NotNil(err, "expected an error while writing through symbolic link, but got no error")
+	actual, err = os.ReadFile(filePath)
+	assert.Nil(err, "unexpected error while reading file", err)
+	assert.Equal("# Title\n\n"+getExpected("new")+"\n", string(actual))
+
+	// Test writing through a symbolic link when it's enabled, the link is kept
+	fw.FollowSymlinks = true
+	_, err = io.WriteString(fw, "linked")
+	assert.Nil(err, "unexpected error while writing through symbolic link", err)
+	linkInfo, err := os.Lstat(linkPath)
+	assert.Nil(err, "unexpected error while reading link info", err)
+	assert.NotZero(linkInfo.Mode()&os.ModeSymlink, "symbolic link was replaced")
+	actual, err = os.ReadFile(filePath)
+	assert.Nil(err, "unexpected error while reading file", err)
+	assert.Equal("# Title\n\n"+getExpected("linked")+"\n", string(actual))
+
+	// Test new files get the permissions of the umask, like other new files
+	fw.Filepath = filepath.Join(dir, "new.md")
+	_, err = io.WriteString(fw, "new")
+	assert.Nil(err, "unexpected error while writing new file", err)
+	reference, err := os.OpenFile(filepath.Join(dir, "reference"), os.O_CREATE|os.O_WRONLY, 0666)
+	assert.Nil(err, "unexpected error while creating reference file", err)
+	defer reference.Close()
+	referenceInfo, err := reference.Stat()
+	assert.Nil(err, "unexpected error while reading reference file info", err)
+	info, err = os.Stat(fw.Filepath)
+	assert.Nil(err, "unexpected error while reading file info", err)
+	assert.Equal(referenceInfo.Mode().Perm(), info.Mode().Perm())
+
+	// Test writing a directory fails
+	fw.Filepath = dir
+	_, err = io.WriteString(fw, "new")
+	assert.NotNil(err, "expected an error when writing a directory, but got no error")
+}
+
+func TestFileWriter_Write_LineTags(t *testing.T) {
+	assert := assert.New(t)
+
+	// Prepare test data, a previous asciidoc section with tags inside table cells
+	filePath := "output-line.adoc"
+	beginTag, endTag := config.GetMarkers(config.FormatAsciiDoc, config.Markers{}).Tags("", "")
+	existing := "= Title\n\n" + beginTag + "\n\n|pass:c[" + endTag + "]\n|" + beginTag + " x\n\n" + endTag + "\n\nfooter\n"
+	err := os.WriteFile(filePath, []byte(existing), 0644)
+	assert.Nil(err, "unexpected error while creating file", err)
+	defer os.Remove(filePath)
+
+	fw := &FileWriter{
+		Filepath:   filePath,
+		Template:   config.GetTagsTemplate(beginTag, endTag),
+		OpeningTag: beginTag,
+		ClosingTag: endTag,
+		LineTags:   true,
+		Logger:     &testLogger[0],
+	}
+
+	// Test that only line tags alone on their line delimit the section
+	_, err = io.WriteString(fw, "foo")
+	assert.Nil(err, "unexpected error while writing to file with line tags", err)
+
+	// Verify file content
+	actual, err := os.ReadFile(filePath)
+	assert.Nil(err, "unexpected error while reading file", err)
+	assert.Equal("= Title\n\n"+beginTag+"\n\nfoo\n\n"+endTag+"\n\nfooter\n", string(actual))
+
+	// Test that line tags inside a line fail instead of appending a second section
+	err = os.WriteFile(filePath, []byte("= Title "+beginTag+"\n"+endTag+" footer\n"), 0644)
+	assert.Nil(err, "unexpected error while writing file", err)
+	_, err = io.WriteString(fw, "foo")
+	assert.NotNil(err, "expected an error for line tags inside a line, but got no error")
+}
+
+func TestFileWriter_Write_InlineTags(t *testing.T) {
+	assert := assert.New(t)
+
+	// Prepare test data
+	filePath := "output-inline.md"
+	defer os.Remove(filePath)
+	fw := &FileWriter{
+		Filepath:   filePath,
+		Template:   testTemplate,
+		OpeningTag: testBeginTag,
+		ClosingTag: testEndTag,
+		Logger:     &testLogger[0],
+	}
+
+	tests := []struct {
+		existing string
+		expected string
+	}{
+		// both tags on the same line
+		{"# Title\n" + testBeginTag + testEndTag + "\n", "# Title\n" + getExpected("foo") + "\n"},
+		// text after the closing tag
+		{"# Title\n" + testBeginTag + "\nold\n" + testEndTag + " footer\n", "# Title\n" + getExpected("foo") + " footer\n"},
+	}
+
+	// Test that HTML comment tags delimit the section inside a line
+	for _, tt := range tests {
+		err := os.WriteFile(filePath, []byte(tt.existing), 0644)
+		assert.Nil(err, "unexpected error while creating file", err)
+		_, err = io.WriteString(fw, "foo")
+		assert.Nil(err, "unexpected error while writing to file with inline tags %q", tt.existing)
+		actual, err := os.ReadFile(filePath)
+		assert.Nil(err, "unexpected error while reading file", err)
+		assert.Equal(tt.expected, string(actual))
+	}
+}
+
+func TestFileWriter_Content(t *testing.T) {
+	assert := assert.New(t)
+
+	// Prepare test data
+	filePath := "output-content.md"
+	existing := "# Title\n\n" + getExpected("old") + "\n"
+	err := os.WriteFile(filePath, []byte(existing), 0644)
+	assert.Nil(err, "unexpected error while creating file", err)
+	defer os.Remove(filePath)
+
+	fw := &FileWriter{
+		Filepath:   filePath,
+		Template:   testTemplate,
+		OpeningTag: testBeginTag,
+		ClosingTag: testEndTag,
+		Logger:     &testLogger[0],
+	}
+
+	// Test getting the current and updated content
+	current, updated, err := fw.Content([]byte("new"))
+	assert.Nil(err, "unexpected error while getting content", err)
+	assert.Equal(existing, current)
+	assert.Equal("# Title\n\n"+getExpected("new")+"\n", updated)
+
+	// Verify the file isn't written
+	actual, err := os.ReadFile(filePath)
+	assert.Nil(err, "unexpected error while reading file", err)
+	assert.Equal(existing, string(actual))
+
+	// Test getting the content of a missing file
+	fw.Filepath = "missing.md"
+	current, updated, err = fw.Content([]byte("new"))
+	assert.Nil(err, "unexpected error while getting content", err)
+	assert.Empty(current)
+	assert.Equal(getExpected("new"), updated)
+}
+
+func TestFileWriter_Content_Modes(t *testing.T) {
+	assert := assert.New(t)
+
+	// Prepare test data
+	filePath := "output-modes.md"
+	defer os.Remove(filePath)
+	section := "# Title\n\n" + getExpected("old") + "\n"
+
+	tests := []struct {
+		mode     string
+		existing string
+		expected string
+	}{
+		{config.OutputModeInject, section, "# Title\n\n" + getExpected("new") + "\n"},
+		{config.OutputModeReplace, section, getExpected("new")},
+		{config.OutputModeReplace, "# Title\n", getExpected("new")},
+		{config.OutputModeAppend, section, "# Title\n\n" + getExpected("new") + "\n"},
+		{config.OutputModeAppend, "# Title\n", "# Title\n\n" + getExpected("new")},
+		{config.OutputModePrepend, section, "# Title\n\n" + getExpected("new") + "\n"},
+		{config.OutputModePrepend, "# Title\n", getExpected("new") + "\n\n# Title\n"},
+		{"", "# Title\n", "# Title\n\n" + getExpected("new")},
+	}
+
+	for _, tt := range tests {
+		err := os.WriteFile(filePath, []byte(tt.existing), 0644)
+		assert.Nil(err, "unexpected error while creating file", err)
+		fw := &FileWriter{
+			Filepath:   filePath,
+			Template:   testTemplate,
+			OpeningTag: testBeginTag,
+			ClosingTag: testEndTag,
+			Mode:       tt.mode,
+			Logger:     &testLogger[0],
+		}
+		_, updated, err := fw.Content([]byte("new"))
+		assert.Nil(err, "unexpected error in mode %q", tt.mode)
+		assert.Equal(tt.expected, updated, "unexpected content in mode %q", tt.mode)
+	}
+
+	// Test inject mode fails without tags, in an empty file or without file
+	fw := &FileWriter{
+		Filepath:   filePath,
+		Template:   testTemplate,
+		OpeningTag: testBeginTag,
+		ClosingTag: testEndTag,
+		Mode:       config.OutputModeInject,
+		Logger:     &testLogger[0],
+	}
+	for _, existing := range []string{"# Title\n", ""} {
+		err := os.WriteFile(filePath, []byte(existing), 0644)
+		assert.Nil(err, "unexpected error while creating file", err)
+		_, _, err = fw.Content([]byte("new"))
+		assert.NotNil(err, "expected an error in inject mode for content %q, but got no error", existing)
+	}
+	fw.Filepath = "missing.md"
+	_, _, err := fw.Content([]byte("new"))
+	assert.NotNil(err, "expected an error in inject mode for a missing file, but got no error")
+}
+
+func TestFileWriter_FindMarker(t *testing.T) {
+	assert := assert.New(t)
+
+	// Prepare test data
+	filePath := "output-marker.md"
+	content := `# Title
+
+Inline <!-- BEGIN_CHECKOV_DOCS format=html --> tags are ignored
+
+<!-- BEGIN_CHECKOV_DOCS name=terraform format=list -->
+<!-- END_CHECKOV_DOCS name=terraform -->
+
+<!-- BEGIN_CHECKOV_DOCS  columns=check_id,reason group-by=file -->
+<!-- END_CHECKOV_DOCS -->
+
+// BEGIN_CHECKOV_DOCS_FAILED summary=true
+// END_CHECKOV_DOCS_FAILED
+`
+	err := os.WriteFile(filePath, []byte(content), 0644)
+	assert.Nil(err, "unexpected error while creating file", err)
+	defer os.Remove(filePath)
+
+	tests := []struct {
+		openingTag string
+		expected   *Marker
+	}{
+		{
+			testBeginTag,
+			&Marker{
+				Tag:     "<!-- BEGIN_CHECKOV_DOCS  columns=check_id,reason group-by=file -->",
+				Options: []Option{{Key: "columns", Value: "check_id,reason"}, {Key: "group-by", Value: "file"}},
+			},
+		},
+		{
+			"<!-- BEGIN_CHECKOV_DOCS name=terraform -->",
+			&Marker{Tag: "<!-- BEGIN_CHECKOV_DOCS name=terraform format=list -->", Options: []Option{{Key: "format", Value: "list"}}},
+		},
+		{"// BEGIN_CHECKOV_DOCS_FAILED", &Marker{Tag: "// BEGIN_CHECKOV_DOCS_FAILED summary=true", Options: []Option{{Key: "summary", Value: "true"}}}},
+		{"<!-- BEGIN_CHECKOV_DOCS name=kubernetes -->", nil},
+		{"<!-- BEGIN_CHECKOV_DOCS_PASSED -->", nil},
+	}
+
+	for _, tt := range tests {
+		fw := &FileWriter{Filepath: filePath, OpeningTag: tt.openingTag, Logger: &testLogger[0]}
+		marker, err := fw.FindMarker()
+		assert.Nil(err, "unexpected error while finding marker", err)
+		assert.Equal(tt.expected, marker, "unexpected marker for %q", tt.openingTag)
+	}
+
+	// Test finding a marker in a missing file
+	fw := &FileWriter{Filepath: "missing.md", OpeningTag: testBeginTag, Logger: &testLogger[0]}
+	marker, err := fw.FindMarker()
+	assert.Nil(err, "unexpected error while finding marker in missing file", err)
+	assert.Nil(marker)
+}
+
 func TestFileWriter_render(t *testing.T) {
 	assert := assert.New(t)
 
 	// Prepare test data
 	input := []byte("Hello, world!")
 	fw := &FileWriter{
-		Template: config.OutputTemplate,
+		Template: testTemplate,
 		Logger:   &testLogger[0],
 	}
 
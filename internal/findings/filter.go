@@ -0,0 +1,159 @@
+/*
+Copyright © 2023 The checkov-docs Authors
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package findings
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/checkov-docs/checkov-docs/internal/models"
+)
+
+// Filter selects the checks whose field matches one of its patterns
+type Filter struct {
+	// Field is the name of the check field to match, see models.Fields
+	Field string
+	// Patterns are matched against the field value, ignoring case.
+	// `*` matches any sequence of characters and `?` matches a single character.
+	Patterns []string
+	// Negate selects the checks whose field doesn't match any pattern
+	Negate bool
+}
+
+// ParseFilter returns the filter represented by `value`, either `field=pattern[,pattern]`
+// to select matching checks or `field!=pattern[,pattern]` to exclude them
+func ParseFilter(value string) (*Filter, error) {
+	field, patterns, found := strings.Cut(value, "=")
+	if !found {
+		return nil, fmt.Errorf("invalid filter %q, expected field=pattern or field!=pattern", value)
+	}
+
+	filter := &Filter{}
+	if strings.HasSuffix(field, "!") {
+		filter.Negate = true
+		field = strings.TrimSuffix(field, "!")
+	}
+
+	filter.Field = models.FieldName(field)
+	if filter.Field == "" {
+		return nil, fmt.Errorf("invalid filter %q, unknown field %q, valid fields: %v", value, field, models.Fields)
+	}
+
+	for _, pattern := range strings.Split(patterns, ",") {
+		filter.Patterns = append(filter.Patterns, strings.TrimSpace(pattern))
+	}
+
+	return filter, nil
+}
+
+// ParseFilters returns the filters represented by `values`
+func ParseFilters(values []string) ([]*Filter, error) {
+	filters := make([]*Filter, 0, len(values))
+	for _, value := range values {
+		filter, err := ParseFilter(value)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, filter)
+	}
+
+	return filters, nil
+}
+
+// Match returns true if `check` is selected by the filter
+func (f *Filter) Match(check *models.Check) bool {
+	value := strings.ToLower(check.Field(f.Field))
+	for _, pattern := range f.Patterns {
+		if matchWildcard(strings.ToLower(pattern), value) {
+			return !f.Negate
+		}
+	}
+
+	return f.Negate
+}
+
+// String returns the string representation of the filter
+func (f *Filter) String() string {
+	operator := "="
+	if f.Negate {
+		operator = "!="
+	}
+	return f.Field + operator + strings.Join(f.Patterns, ",")
+}
+
+// Apply returns the checks selected by all `filters`
+func Apply(checks []*models.Check, filters []*Filter) []*models.Check {
+	if len(filters) == 0 {
+		return checks
+	}
+
+	selected := make([]*models.Check, 0, len(checks))
+	for _, check := range checks {
+		if matchAll(check, filters) {
+			selected = append(selected, check)
+		}
+	}
+
+	return selected
+}
+
+// matchAll returns true if `check` is selected by all `filters`
+func matchAll(check *models.Check, filters []*Filter) bool {
+	for _, filter := range filters {
+		if !filter.Match(check) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchWildcard returns true if `value` matches `pattern`, where `*` matches any sequence
+// of characters, including path separators, and `?` matches a single character
+func matchWildcard(pattern, value string) bool {
+	p, v := []rune(pattern), []rune(value)
+	i, j := 0, 0
+	star, match := -1, 0
+
+	for j < len(v) {
+		switch {
+		case i < len(p) && (p[i] == '?' || p[i] == v[j]):
+			i++
+			j++
+		case i < len(p) && p[i] == '*':
+			star, match = i, j
+			i++
+		case star >= 0:
+			// backtrack, let the last `*` match one more character
+			i = star + 1
+			match++
+			j = match
+		default:
+			return false
+		}
+	}
+
+	for i < len(p) && p[i] == '*' {
+		i++
+	}
+	return i == len(p)
+}
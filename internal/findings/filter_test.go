@@ -0,0 +1,95 @@
+/*
+Copyright © 2023 The checkov-docs Authors
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package findings
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/checkov-docs/checkov-docs/internal/models"
+)
+
+func TestParseFilter(t *testing.T) {
+	assert := assert.New(t)
+
+	// Test parsing filters, aliases are resolved
+	filter, err := ParseFilter("severity=HIGH,CRITICAL")
+	assert.Nil(err, "unexpected error parsing filter", err)
+	assert.Equal(&Filter{Field: "severity", Patterns: []string{"HIGH", "CRITICAL"}}, filter)
+
+	filter, err = ParseFilter("file!=/modules/*")
+	assert.Nil(err, "unexpected error parsing filter", err)
+	assert.Equal(&Filter{Field: "file_path", Patterns: []string{"/modules/*"}, Negate: true}, filter)
+	assert.Equal("file_path!=/modules/*", filter.String())
+
+	// Test parsing invalid filters
+	_, err = ParseFilter("severity")
+	assert.NotNil(err, "expected an error parsing a filter without pattern, but got no error")
+	_, err = ParseFilter("unknown=value")
+	assert.NotNil(err, "expected an error parsing a filter with an unknown field, but got no error")
+	_, err = ParseFilters([]string{"check_id=CKV_AWS_*", "unknown=value"})
+	assert.NotNil(err, "expected an error parsing filters with an unknown field, but got no error")
+}
+
+func TestApply(t *testing.T) {
+	assert := assert.New(t)
+
+	// Prepare test data
+	checks := []*models.Check{
+		{FilePath: "/main.tf", CheckID: "CKV_AWS_115", Severity: "HIGH", CheckType: "terraform"},
+		{FilePath: "/modules/lambda/main.tf", CheckID: "CKV_AWS_116", Severity: "LOW", CheckType: "terraform"},
+		{FilePath: "/deployment.yaml", CheckID: "CKV_K8S_21", Severity: "critical", CheckType: "kubernetes"},
+	}
+	apply := func(values ...string) []string {
+		filters, err := ParseFilters(values)
+		assert.Nil(err, "unexpected error parsing filters", err)
+		ids := []string{}
+		for _, check := range Apply(checks, filters) {
+			ids = append(ids, check.CheckID)
+		}
+		return ids
+	}
+
+	// Test applying filters, all filters must match
+	assert.Equal([]string{"CKV_AWS_115", "CKV_AWS_116", "CKV_K8S_21"}, apply())
+	assert.Equal([]string{"CKV_AWS_115", "CKV_K8S_21"}, apply("severity=high,CRITICAL"))
+	assert.Equal([]string{"CKV_AWS_115"}, apply("framework=terraform", "file!=/modules/*"))
+	assert.Equal([]string{"CKV_AWS_116"}, apply("file=*/lambda/*", "check_id=CKV_AWS_11?"))
+	assert.Equal([]string{}, apply("check_id=CKV_GCP_*"))
+}
+
+func TestMatchWildcard(t *testing.T) {
+	assert := assert.New(t)
+
+	// Test matching wildcard patterns
+	assert.True(matchWildcard("", ""))
+	assert.True(matchWildcard("*", ""))
+	assert.True(matchWildcard("*", "/modules/lambda/main.tf"))
+	assert.True(matchWildcard("/modules/*.tf", "/modules/lambda/main.tf"))
+	assert.True(matchWildcard("*a*b*", "xaybzb"))
+	assert.True(matchWildcard("ckv_?ws_*", "ckv_aws_115"))
+	assert.False(matchWildcard("", "a"))
+	assert.False(matchWildcard("/modules/*.tf", "/modules/lambda/main.yaml"))
+	assert.False(matchWildcard("ckv_aws_11?", "ckv_aws_1150"))
+}
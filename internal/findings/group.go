@@ -0,0 +1,89 @@
+/*
+Copyright © 2023 The checkov-docs Authors
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package findings
+
+import (
+	"fmt"
+
+	"github.com/checkov-docs/checkov-docs/internal/models"
+)
+
+// GroupFields stores the check fields checks can be grouped by
+var GroupFields = []string{"file_path", "check_id", "check_type", "resource_type"}
+
+// Group stores the checks sharing the same value of a field
+type Group struct {
+	// Key is the value of the field shared by the checks
+	Key string
+	// Checks are the checks of the group, in their original order
+	Checks []*models.Check
+}
+
+// GroupFieldName returns the check field `name` refers to, e.g. `file_path` for `file`.
+// It returns an error if checks can't be grouped by the field.
+func GroupFieldName(name string) (string, error) {
+	field := models.FieldName(name)
+	for _, groupField := range GroupFields {
+		if field == groupField {
+			return field, nil
+		}
+	}
+	return "", fmt.Errorf("invalid group-by field %q, valid values: file, check_id, framework, resource_type", name)
+}
+
+// GroupBy returns the groups of `checks` sharing the same value of `field`,
+// in the order of the first check of each group, see GroupSortKeys to sort them
+func GroupBy(checks []*models.Check, field string) []*Group {
+	groups := []*Group{}
+	index := make(map[string]*Group)
+	for _, check := range checks {
+		key := check.Field(field)
+		group, ok := index[key]
+		if !ok {
+			group = &Group{Key: key}
+			index[key] = group
+			groups = append(groups, group)
+		}
+		group.Checks = append(group.Checks, check)
+	}
+
+	return groups
+}
+
+// GroupSortKeys returns `keys` with the key of `field` moved first, so that groups are sorted
+// by their key and checks of each group by the other keys.
+// The group field is sorted in ascending order unless `keys` sets its direction.
+func GroupSortKeys(keys []*SortKey, field string) []*SortKey {
+	groupKey := &SortKey{Field: field}
+	sorted := make([]*SortKey, 1, len(keys)+1)
+	for _, key := range keys {
+		if key.Field == field {
+			groupKey = key
+			continue
+		}
+		sorted = append(sorted, key)
+	}
+	sorted[0] = groupKey
+
+	return sorted
+}
@@ -0,0 +1,76 @@
+/*
+Copyright © 2023 The checkov-docs Authors
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package findings
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/checkov-docs/checkov-docs/internal/models"
+)
+
+func TestGroupFieldName(t *testing.T) {
+	assert := assert.New(t)
+
+	field, err := GroupFieldName("file")
+	assert.Nil(err, "unexpected error parsing group-by field", err)
+	assert.Equal("file_path", field)
+	field, err = GroupFieldName("framework")
+	assert.Nil(err, "unexpected error parsing group-by field", err)
+	assert.Equal("check_type", field)
+
+	_, err = GroupFieldName("severity")
+	assert.NotNil(err, "expected an error for an invalid group-by field, but got no error")
+}
+
+func TestGroupBy(t *testing.T) {
+	assert := assert.New(t)
+
+	// Prepare test data
+	a := &models.Check{FilePath: "/b.tf", CheckID: "CKV_AWS_2"}
+	b := &models.Check{FilePath: "/a.tf", CheckID: "CKV_AWS_1"}
+	c := &models.Check{FilePath: "/b.tf", CheckID: "CKV_AWS_3"}
+
+	// Test grouping checks, groups keep the order of their first check
+	groups := GroupBy([]*models.Check{a, b, c}, "file_path")
+	assert.Equal([]*Group{
+		{Key: "/b.tf", Checks: []*models.Check{a, c}},
+		{Key: "/a.tf", Checks: []*models.Check{b}},
+	}, groups)
+
+	// Test grouping no checks
+	assert.Empty(GroupBy(nil, "file_path"))
+}
+
+func TestGroupSortKeys(t *testing.T) {
+	assert := assert.New(t)
+
+	// Test prepending the group field
+	keys := GroupSortKeys([]*SortKey{{Field: "severity", Descending: true}}, "file_path")
+	assert.Equal([]*SortKey{{Field: "file_path"}, {Field: "severity", Descending: true}}, keys)
+
+	// Test moving the group field first, keeping its direction
+	keys = GroupSortKeys([]*SortKey{{Field: "severity"}, {Field: "file_path", Descending: true}}, "file_path")
+	assert.Equal([]*SortKey{{Field: "file_path", Descending: true}, {Field: "severity"}}, keys)
+}
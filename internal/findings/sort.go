@@ -0,0 +1,199 @@
+/*
+Copyright © 2023 The checkov-docs Authors
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package findings
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/checkov-docs/checkov-docs/internal/models"
+)
+
+// Sort directions
+const (
+	SortAscending  = "asc"
+	SortDescending = "desc"
+)
+
+// severityRanks stores the rank of checkov severities, from the least to the most severe.
+// Checks without a known severity are ranked below all others.
+var severityRanks = map[string]int{
+	"INFO":     1,
+	"LOW":      2,
+	"MEDIUM":   3,
+	"HIGH":     4,
+	"CRITICAL": 5,
+}
+
+// SortKey orders checks by the value of a field
+type SortKey struct {
+	// Field is the name of the check field to compare, see models.Fields
+	Field string
+	// Descending reverses the order of the field values
+	Descending bool
+}
+
+// ParseSortKey returns the sort key represented by `value`, `field[:asc|:desc]`
+func ParseSortKey(value string) (*SortKey, error) {
+	field, direction, _ := strings.Cut(value, ":")
+
+	key := &SortKey{Field: models.FieldName(field)}
+	if key.Field == "" {
+		return nil, fmt.Errorf("invalid sort key %q, unknown field %q, valid fields: %v", value, field, models.Fields)
+	}
+
+	switch strings.ToLower(strings.TrimSpace(direction)) {
+	case "", SortAscending:
+	case SortDescending:
+		key.Descending = true
+	default:
+		return nil, fmt.Errorf("invalid sort key %q, unknown direction %q, valid values: %s, %s", value, direction, SortAscending, SortDescending)
+	}
+
+	return key, nil
+}
+
+// ParseSortKeys returns the sort keys represented by `values`
+func ParseSortKeys(values []string) ([]*SortKey, error) {
+	keys := make([]*SortKey, 0, len(values))
+	for _, value := range values {
+		key, err := ParseSortKey(value)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+// String returns the string representation of the sort key
+func (k *SortKey) String() string {
+	if k.Descending {
+		return k.Field + ":" + SortDescending
+	}
+	return k.Field + ":" + SortAscending
+}
+
+// Compare returns -1 if `a` sorts before `b`, 1 if `a` sorts after `b` and 0 if both have the same value.
+// Severities are compared by rank and line numbers numerically, other fields in natural order, see compareStrings.
+func (k *SortKey) Compare(a, b *models.Check) int {
+	var c int
+	switch k.Field {
+	case "severity":
+		c = compareInts(severityRanks[strings.ToUpper(a.Severity)], severityRanks[strings.ToUpper(b.Severity)])
+	case "start_line", "file_line_range":
+		c = compareInts(a.StartLine(), b.StartLine())
+	case "end_line":
+		c = compareInts(a.EndLine(), b.EndLine())
+	default:
+		c = compareStrings(a.Field(k.Field), b.Field(k.Field))
+	}
+
+	if k.Descending {
+		return -c
+	}
+	return c
+}
+
+// Sort sorts `checks` in place by `keys`, later keys break the ties of earlier keys.
+// Checks with the same value for all keys keep their order.
+func Sort(checks []*models.Check, keys []*SortKey) {
+	if len(keys) == 0 {
+		return
+	}
+
+	sort.SliceStable(checks, func(i, j int) bool {
+		for _, key := range keys {
+			if c := key.Compare(checks[i], checks[j]); c != 0 {
+				return c < 0
+			}
+		}
+		return false
+	})
+}
+
+// compareStrings compares `a` and `b` ignoring case, in natural order: runs of digits are compared by their
+// numeric value, e.g. `file2.tf` sorts before `file10.tf`. Values with the same natural order, e.g. `01` and `1`,
+// are compared as strings so that the order is total. It returns -1, 0 or 1.
+func compareStrings(a, b string) int {
+	a, b = strings.ToLower(a), strings.ToLower(b)
+	restA, restB := a, b
+	for restA != "" && restB != "" {
+		var x, y string
+		x, restA = nextChunk(restA)
+		y, restB = nextChunk(restB)
+		if c := compareChunks(x, y); c != 0 {
+			return c
+		}
+	}
+	if c := compareInts(len(restA), len(restB)); c != 0 {
+		return c
+	}
+	return strings.Compare(a, b)
+}
+
+// nextChunk returns the leading run of digits or of other characters of `s`, and the rest of `s`
+func nextChunk(s string) (string, string) {
+	digits := isDigit(s[0])
+	i := 1
+	for i < len(s) && isDigit(s[i]) == digits {
+		i++
+	}
+	return s[:i], s[i:]
+}
+
+// compareChunks compares runs of digits by their numeric value, without overflow, and other runs as strings.
+// A run of digits and another run are compared by their first character.
+func compareChunks(x, y string) int {
+	switch xDigits, yDigits := isDigit(x[0]), isDigit(y[0]); {
+	case xDigits && yDigits:
+		x, y = strings.TrimLeft(x, "0"), strings.TrimLeft(y, "0")
+		if c := compareInts(len(x), len(y)); c != 0 {
+			return c
+		}
+		return strings.Compare(x, y)
+	case xDigits || yDigits:
+		return compareInts(int(x[0]), int(y[0]))
+	default:
+		return strings.Compare(x, y)
+	}
+}
+
+// compareInts returns -1, 0 or 1 if `x` is less than, equal to or greater than `y`
+func compareInts(x, y int) int {
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// isDigit returns true if `c` is an ASCII digit
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
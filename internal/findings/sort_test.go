@@ -0,0 +1,109 @@
+/*
+Copyright © 2023 The checkov-docs Authors
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package findings
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/checkov-docs/checkov-docs/internal/models"
+)
+
+func TestParseSortKey(t *testing.T) {
+	assert := assert.New(t)
+
+	// Test parsing sort keys, aliases are resolved
+	key, err := ParseSortKey("file")
+	assert.Nil(err, "unexpected error parsing sort key", err)
+	assert.Equal(&SortKey{Field: "file_path"}, key)
+	key, err = ParseSortKey("severity:DESC")
+	assert.Nil(err, "unexpected error parsing sort key", err)
+	assert.Equal(&SortKey{Field: "severity", Descending: true}, key)
+	assert.Equal("severity:desc", key.String())
+
+	// Test parsing invalid sort keys
+	_, err = ParseSortKey("unknown")
+	assert.NotNil(err, "expected an error for an unknown field, but got no error")
+	_, err = ParseSortKey("file:up")
+	assert.NotNil(err, "expected an error for an unknown direction, but got no error")
+}
+
+func TestSort(t *testing.T) {
+	assert := assert.New(t)
+
+	// Prepare test data
+	a := &models.Check{FilePath: "/b.tf", CheckID: "CKV_AWS_2", Severity: "LOW", FileLineRange: []int{10, 12}}
+	b := &models.Check{FilePath: "/a.tf", CheckID: "CKV_AWS_10", Severity: "CRITICAL", FileLineRange: []int{2, 4}}
+	c := &models.Check{FilePath: "/a.tf", CheckID: "CKV_AWS_1", FileLineRange: []int{9, 9}}
+	d := &models.Check{FilePath: "/b.tf", CheckID: "CKV_AWS_3", Severity: "high", FileLineRange: []int{1, 3}}
+
+	tests := []struct {
+		name     string
+		keys     []string
+		expected []*models.Check
+	}{
+		{"no keys", nil, []*models.Check{a, b, c, d}},
+		{"file", []string{"file"}, []*models.Check{b, c, a, d}},
+		{"file and line", []string{"file", "line:desc"}, []*models.Check{c, b, a, d}},
+		{"check id", []string{"check_id"}, []*models.Check{c, a, d, b}},
+		{"severity", []string{"severity:desc"}, []*models.Check{b, d, a, c}},
+		{"severity ascending", []string{"severity"}, []*models.Check{c, a, d, b}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys, err := ParseSortKeys(tt.keys)
+			assert.Nil(err, "unexpected error parsing sort keys", err)
+			checks := []*models.Check{a, b, c, d}
+			Sort(checks, keys)
+			assert.Equal(tt.expected, checks)
+		})
+	}
+}
+
+func TestCompareStrings(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Negative(compareStrings("2", "10"))
+	assert.Negative(compareStrings("a", "B"))
+	assert.Zero(compareStrings("abc", "ABC"))
+	assert.Positive(compareStrings("b", "a"))
+
+	// Test natural order, which is transitive when numbers and other values are mixed
+	sorted := []string{"", "-1", "01", "1", "1a", "9", "10", "99999999999999999999", "a", "a2.tf", "A10.tf", "a10b"}
+	for i := range sorted {
+		for j := range sorted {
+			expected := compareInts(i, j)
+			assert.Equal(expected, compareStrings(sorted[i], sorted[j]), "unexpected comparison of %q and %q", sorted[i], sorted[j])
+		}
+	}
+
+	// Test sorting gives the same order whatever the input order
+	for i := range sorted {
+		values := append(append([]string{}, sorted[i:]...), sorted[:i]...)
+		sort.SliceStable(values, func(a, b int) bool { return compareStrings(values[a], values[b]) < 0 })
+		assert.Equal(sorted, values, "unexpected order of values rotated by %d", i)
+	}
+}
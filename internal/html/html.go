@@ -0,0 +1,160 @@
+/*
+Copyright © 2023 The checkov-docs Authors
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package html
+
+import (
+	"html"
+	"net/url"
+	"strings"
+
+	"github.com/checkov-docs/checkov-docs/internal/config"
+	"github.com/checkov-docs/checkov-docs/internal/logger"
+	"github.com/checkov-docs/checkov-docs/internal/models"
+	"github.com/checkov-docs/checkov-docs/internal/table"
+)
+
+// PageTitle is the title of standalone HTML pages
+const PageTitle = "Checkov results"
+
+// pageStyle is the CSS embedded in standalone HTML pages
+const pageStyle = `body {
+  font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Helvetica, Arial, sans-serif;
+  color: #1f2328;
+  margin: 2rem;
+}
+table {
+  border-collapse: collapse;
+  margin: 1rem 0;
+}
+th, td {
+  border: 1px solid #d0d7de;
+  padding: 6px 13px;
+  text-align: left;
+  vertical-align: top;
+}
+th {
+  background-color: #f6f8fa;
+}
+tr:nth-child(even) td {
+  background-color: #f6f8fa;
+}
+code {
+  font-family: ui-monospace, SFMono-Regular, Menlo, Consolas, monospace;
+  font-size: 85%;
+}
+summary {
+  cursor: pointer;
+  font-weight: 600;
+}`
+
+// newLineReplacer converts new lines of escaped values to line breaks
+var newLineReplacer = strings.NewReplacer("\r\n", "<br>", "\n", "<br>", "\r", "<br>")
+
+// revive:disable:unhandled-error writing to a strings.Builder doesn't fail
+
+// WriteTable returns an HTML table with arguments headers and rows, values are escaped
+func WriteTable(headers []string, rows [][]table.Cell, logger *logger.Logger) (string, error) {
+	logger.Info("create html table")
+	var sb strings.Builder
+
+	sb.WriteString("<table>\n  <thead>\n    <tr>\n")
+	for _, header := range headers {
+		sb.WriteString("      <th>" + html.EscapeString(header) + "</th>\n")
+	}
+	sb.WriteString("    </tr>\n  </thead>\n  <tbody>\n")
+	logger.Debug("added header row")
+
+	for _, row := range rows {
+		sb.WriteString("    <tr>\n")
+		for _, cell := range row {
+			sb.WriteString("      <td>" + FormatCell(cell) + "</td>\n")
+		}
+		sb.WriteString("    </tr>\n")
+	}
+	sb.WriteString("  </tbody>\n</table>")
+	logger.Debug("added data rows")
+
+	return sb.String(), nil
+}
+
+// WriteSummary returns an HTML table with a single row containing the totals in `summary`
+func WriteSummary(headers []string, summary *models.Summary, logger *logger.Logger) (string, error) {
+	logger.Info("create html summary")
+
+	return WriteTable(headers, [][]table.Cell{table.SummaryRow(summary)}, logger)
+}
+
+// WriteGroup returns `content` in a `<details>` element with `title` as summary
+func WriteGroup(title, content string) string {
+	if title == "" {
+		title = html.EscapeString(config.GroupTitleEmpty)
+	} else {
+		title = "<code>" + html.EscapeString(title) + "</code>"
+	}
+	return "<details>\n<summary>" + title + "</summary>\n\n" + content + "\n</details>"
+}
+
+// WritePage returns a standalone HTML page with embedded CSS and `content` as body
+func WritePage(content string) string {
+	var sb strings.Builder
+
+	sb.WriteString("<!DOCTYPE html>\n<html lang=\"en\">\n<head>\n")
+	sb.WriteString("<meta charset=\"utf-8\">\n")
+	sb.WriteString("<meta name=\"viewport\" content=\"width=device-width, initial-scale=1\">\n")
+	sb.WriteString("<title>" + PageTitle + "</title>\n")
+	sb.WriteString("<style>\n" + pageStyle + "\n</style>\n")
+	sb.WriteString("</head>\n<body>\n<main>\n")
+	sb.WriteString("<h1>" + PageTitle + "</h1>\n\n")
+	sb.WriteString(content)
+	sb.WriteString("\n</main>\n</body>\n</html>")
+
+	return sb.String()
+}
+
+// revive:enable:unhandled-error
+
+// FormatCell returns the content of `cell` escaped for an HTML table cell.
+// New lines are converted to line breaks and links are only rendered for safe URLs.
+func FormatCell(cell table.Cell) string {
+	text := newLineReplacer.Replace(html.EscapeString(cell.Value))
+	if cell.Code && text != "" {
+		text = "<code>" + text + "</code>"
+	}
+	if text == "" || !isSafeURL(cell.URL) {
+		return text
+	}
+	return "<a href=\"" + html.EscapeString(cell.URL) + "\">" + text + "</a>"
+}
+
+// isSafeURL returns true if `rawURL` is a valid HTTP(S) URL, which can't run scripts when followed
+func isSafeURL(rawURL string) bool {
+	if rawURL == "" {
+		return false
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	scheme := strings.ToLower(u.Scheme)
+	return scheme == "http" || scheme == "https"
+}
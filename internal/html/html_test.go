@@ -0,0 +1,122 @@
+/*
+Copyright © 2023 The checkov-docs Authors
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package html
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/checkov-docs/checkov-docs/internal/logger"
+	"github.com/checkov-docs/checkov-docs/internal/models"
+	"github.com/checkov-docs/checkov-docs/internal/table"
+)
+
+func TestWriteTable(t *testing.T) {
+	assert := assert.New(t)
+	logger := logger.NewMockLogger(&bytes.Buffer{})
+
+	// Prepare test data
+	headers := []string{"Check ID", "Reason"}
+	rows := [][]table.Cell{
+		{{Value: "CKV_AWS_18", URL: "https://docs.example.com/?id=18&lang=en"}, {Value: "<b>logs</b>\nare off", Code: true}},
+		{{Value: "CKV_AWS_21"}, {Value: "R&D \"only\""}},
+	}
+	expected := `
+<table>
+  <thead>
+    <tr>
+      <th>Check ID</th>
+      <th>Reason</th>
+    </tr>
+  </thead>
+  <tbody>
+    <tr>
+      <td><a href="https://docs.example.com/?id=18&amp;lang=en">CKV_AWS_18</a></td>
+      <td><code>&lt;b&gt;logs&lt;/b&gt;<br>are off</code></td>
+    </tr>
+    <tr>
+      <td>CKV_AWS_21</td>
+      <td>R&amp;D &#34;only&#34;</td>
+    </tr>
+  </tbody>
+</table>
+`
+
+	// Test writing table
+	output, err := WriteTable(headers, rows, logger)
+	assert.Nil(err, "unexpected error writing table", err)
+	assert.Equal(strings.TrimSpace(expected), output)
+}
+
+func TestWriteSummary(t *testing.T) {
+	assert := assert.New(t)
+	logger := logger.NewMockLogger(&bytes.Buffer{})
+
+	// Test writing summary
+	summary := &models.Summary{Passed: 12, Failed: 2, Skipped: 1, ResourceCount: 5, CheckovVersion: "2.3.296"}
+	output, err := WriteSummary([]string{"Passed", "Failed", "Skipped", "Parsing Errors", "Resources", "Checkov Version"}, summary, logger)
+	assert.Nil(err, "unexpected error writing summary", err)
+	assert.Contains(output, "<th>Checkov Version</th>")
+	assert.Contains(output, "      <td>12</td>\n      <td>2</td>\n      <td>1</td>\n      <td>0</td>\n      <td>5</td>\n      <td>2.3.296</td>\n")
+}
+
+func TestWriteGroup(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("<details>\n<summary><code>/main.tf &amp; &lt;b&gt;</code></summary>\n\n<table></table>\n</details>", WriteGroup("/main.tf & <b>", "<table></table>"))
+	assert.Equal("<details>\n<summary>(none)</summary>\n\n<table></table>\n</details>", WriteGroup("", "<table></table>"))
+}
+
+func TestWritePage(t *testing.T) {
+	assert := assert.New(t)
+
+	output := WritePage("<table></table>")
+	assert.True(strings.HasPrefix(output, "<!DOCTYPE html>\n"))
+	assert.Contains(output, "<style>\n")
+	assert.Contains(output, "<h1>Checkov results</h1>\n\n<table></table>\n</main>")
+	assert.True(strings.HasSuffix(output, "</html>"))
+}
+
+func TestFormatCell(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		cell     table.Cell
+		expected string
+	}{
+		{table.Cell{}, ""},
+		{table.Cell{Value: "a | b"}, "a | b"},
+		{table.Cell{Value: "<!-- END_CHECKOV_DOCS -->"}, "&lt;!-- END_CHECKOV_DOCS --&gt;"},
+		{table.Cell{Value: "CKV_AWS_18", URL: "HTTPS://example.com/a\"b"}, "<a href=\"HTTPS://example.com/a&#34;b\">CKV_AWS_18</a>"},
+		{table.Cell{Value: "CKV_AWS_18", URL: "javascript:alert(1)"}, "CKV_AWS_18"},
+		{table.Cell{Value: "CKV_AWS_18", URL: "data:text/html,<script>"}, "CKV_AWS_18"},
+		{table.Cell{URL: "https://example.com"}, ""},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(tt.expected, FormatCell(tt.cell), "unexpected formatted cell for %+v", tt.cell)
+	}
+}
@@ -0,0 +1,181 @@
+/*
+Copyright © 2023 The checkov-docs Authors
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package links
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/checkov-docs/checkov-docs/internal/models"
+)
+
+// Forges whose file URL templates are built in
+const (
+	ForgeGitHub    = "github"
+	ForgeGitLab    = "gitlab"
+	ForgeBitbucket = "bitbucket"
+	ForgeGitea     = "gitea"
+)
+
+// FileURLTemplates stores the URL template of a file line range for each forge, see FileData
+var FileURLTemplates = map[string]string{
+	ForgeGitHub:    "{{.RepoURL}}/blob/{{.Ref}}/{{.Path}}{{if .Start}}#L{{.Start}}-L{{.End}}{{end}}",
+	ForgeGitLab:    "{{.RepoURL}}/-/blob/{{.Ref}}/{{.Path}}{{if .Start}}#L{{.Start}}-{{.End}}{{end}}",
+	ForgeBitbucket: "{{.RepoURL}}/src/{{.Ref}}/{{.Path}}{{if .Start}}#lines-{{.Start}}:{{.End}}{{end}}",
+	ForgeGitea:     "{{.RepoURL}}/src/branch/{{.Ref}}/{{.Path}}{{if .Start}}#L{{.Start}}-L{{.End}}{{end}}",
+}
+
+// DefaultRef is the git reference files are linked to if none is set
+const DefaultRef = "HEAD"
+
+// Options stores the settings used to link checks
+type Options struct {
+	// Guidelines links check IDs to the guideline of the check
+	Guidelines bool
+	// RepoURL is the URL of the repository files are linked to, files aren't linked if empty
+	RepoURL string
+	// Ref is the git branch, tag or commit files are linked to, defaults to DefaultRef
+	Ref string
+	// Forge selects the built-in file URL template, defaults to GitHub
+	Forge string
+	// FileURLTemplate overrides the file URL template of the forge, see FileData
+	FileURLTemplate string
+}
+
+// FileData stores the values available in file URL templates
+type FileData struct {
+	// RepoURL is the URL of the repository, without trailing slash
+	RepoURL string
+	// Ref is the git branch, tag or commit
+	Ref string
+	// Path is the path of the file relative to the repository root
+	Path string
+	// Start is the first line of the check, 0 if unknown
+	Start int
+	// End is the last line of the check, 0 if unknown
+	End int
+}
+
+// Linker returns the URL of check fields
+type Linker struct {
+	guidelines bool
+	repoURL    string
+	ref        string
+	fileURL    *template.Template
+}
+
+// New returns a linker configured by `opts`, or nil if `opts` doesn't link any field
+func New(opts *Options) (*Linker, error) {
+	if opts == nil || (!opts.Guidelines && opts.RepoURL == "" && opts.FileURLTemplate == "") {
+		return nil, nil
+	}
+
+	l := &Linker{
+		guidelines: opts.Guidelines,
+		repoURL:    strings.TrimSuffix(opts.RepoURL, "/"),
+		ref:        opts.Ref,
+	}
+	if l.ref == "" {
+		l.ref = DefaultRef
+	}
+
+	text := opts.FileURLTemplate
+	if text == "" && opts.RepoURL != "" {
+		forge := strings.ToLower(opts.Forge)
+		if forge == "" {
+			forge = ForgeGitHub
+		}
+		var ok bool
+		if text, ok = FileURLTemplates[forge]; !ok {
+			return nil, fmt.Errorf("invalid forge %q, valid values: %v", opts.Forge, Forges())
+		}
+	}
+	if text != "" {
+		tpl, err := template.New("file-url").Option("missingkey=error").Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse file URL template: %w", err)
+		}
+		l.fileURL = tpl
+	}
+
+	return l, nil
+}
+
+// Forges returns the names of the forges with a built-in file URL template
+func Forges() []string {
+	forges := make([]string, 0, len(FileURLTemplates))
+	for forge := range FileURLTemplates {
+		forges = append(forges, forge)
+	}
+	sort.Strings(forges)
+	return forges
+}
+
+// URL returns the URL the check field `field` links to, empty if the field isn't linked.
+// Check IDs link to their guideline and file paths to their line range in the repository.
+func (l *Linker) URL(field string, check *models.Check) (string, error) {
+	if l == nil {
+		return "", nil
+	}
+
+	switch field {
+	case "check_id", "bc_check_id":
+		if l.guidelines {
+			return check.Guideline, nil
+		}
+	case "file_path", "repo_file_path":
+		if l.fileURL != nil {
+			return l.FileURL(check)
+		}
+	}
+
+	return "", nil
+}
+
+// FileURL returns the URL of the line range of `check` in the repository, empty if the check has no file
+func (l *Linker) FileURL(check *models.Check) (string, error) {
+	path := check.RepoFilePath
+	if path == "" {
+		path = check.FilePath
+	}
+	if path == "" || l.fileURL == nil {
+		return "", nil
+	}
+
+	data := &FileData{
+		RepoURL: l.repoURL,
+		Ref:     l.ref,
+		Path:    strings.TrimPrefix(path, "/"),
+		Start:   check.StartLine(),
+		End:     check.EndLine(),
+	}
+	var buf bytes.Buffer
+	if err := l.fileURL.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to execute file URL template: %w", err)
+	}
+
+	return buf.String(), nil
+}
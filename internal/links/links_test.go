@@ -0,0 +1,134 @@
+/*
+Copyright © 2023 The checkov-docs Authors
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package links
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/checkov-docs/checkov-docs/internal/models"
+)
+
+func TestNew(t *testing.T) {
+	assert := assert.New(t)
+
+	// Test options without links
+	linker, err := New(&Options{Ref: "main", Forge: ForgeGitLab})
+	assert.Nil(err, "unexpected error creating linker", err)
+	assert.Nil(linker)
+
+	// Test invalid options
+	_, err = New(&Options{RepoURL: "https://example.com/org/repo", Forge: "unknown"})
+	assert.NotNil(err, "expected an error for an invalid forge, but got no error")
+	_, err = New(&Options{FileURLTemplate: "{{ .Path "})
+	assert.NotNil(err, "expected an error for an invalid template, but got no error")
+}
+
+func TestLinker_FileURL(t *testing.T) {
+	assert := assert.New(t)
+
+	// Prepare test data
+	check := &models.Check{FilePath: "/main.tf", RepoFilePath: "/modules/lambda/main.tf", FileLineRange: []int{3, 12}}
+	noLines := &models.Check{FilePath: "/Dockerfile"}
+
+	tests := []struct {
+		name     string
+		opts     *Options
+		expected string
+		noLines  string
+	}{
+		{
+			"github",
+			&Options{RepoURL: "https://github.com/org/repo/"},
+			"https://github.com/org/repo/blob/HEAD/modules/lambda/main.tf#L3-L12",
+			"https://github.com/org/repo/blob/HEAD/Dockerfile",
+		},
+		{
+			"gitlab",
+			&Options{RepoURL: "https://gitlab.com/org/repo", Ref: "main", Forge: "GitLab"},
+			"https://gitlab.com/org/repo/-/blob/main/modules/lambda/main.tf#L3-12",
+			"https://gitlab.com/org/repo/-/blob/main/Dockerfile",
+		},
+		{
+			"bitbucket",
+			&Options{RepoURL: "https://bitbucket.org/org/repo", Ref: "v1.0.0", Forge: ForgeBitbucket},
+			"https://bitbucket.org/org/repo/src/v1.0.0/modules/lambda/main.tf#lines-3:12",
+			"https://bitbucket.org/org/repo/src/v1.0.0/Dockerfile",
+		},
+		{
+			"gitea",
+			&Options{RepoURL: "https://gitea.com/org/repo", Ref: "main", Forge: ForgeGitea},
+			"https://gitea.com/org/repo/src/branch/main/modules/lambda/main.tf#L3-L12",
+			"https://gitea.com/org/repo/src/branch/main/Dockerfile",
+		},
+		{
+			"custom template",
+			&Options{FileURLTemplate: "https://code.example.com/{{.Path}}?ref={{.Ref}}&line={{.Start}}"},
+			"https://code.example.com/modules/lambda/main.tf?ref=HEAD&line=3",
+			"https://code.example.com/Dockerfile?ref=HEAD&line=0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			linker, err := New(tt.opts)
+			assert.Nil(err, "unexpected error creating linker", err)
+
+			url, err := linker.URL("file_path", check)
+			assert.Nil(err, "unexpected error creating file URL", err)
+			assert.Equal(tt.expected, url)
+			url, err = linker.URL("file_path", noLines)
+			assert.Nil(err, "unexpected error creating file URL", err)
+			assert.Equal(tt.noLines, url)
+		})
+	}
+}
+
+func TestLinker_URL(t *testing.T) {
+	assert := assert.New(t)
+
+	// Prepare test data
+	check := &models.Check{FilePath: "/main.tf", CheckID: "CKV_AWS_18", Guideline: "https://docs.example.com/ckv-aws-18"}
+
+	// Test linking check IDs to guidelines
+	linker, err := New(&Options{Guidelines: true})
+	assert.Nil(err, "unexpected error creating linker", err)
+	url, err := linker.URL("check_id", check)
+	assert.Nil(err, "unexpected error creating URL", err)
+	assert.Equal("https://docs.example.com/ckv-aws-18", url)
+
+	// Test fields without links
+	url, err = linker.URL("file_path", check)
+	assert.Nil(err, "unexpected error creating URL", err)
+	assert.Empty(url)
+	url, err = linker.URL("resource", check)
+	assert.Nil(err, "unexpected error creating URL", err)
+	assert.Empty(url)
+
+	// Test a nil linker
+	var nilLinker *Linker
+	url, err = nilLinker.URL("check_id", check)
+	assert.Nil(err, "unexpected error creating URL", err)
+	assert.Empty(url)
+}
@@ -23,15 +23,52 @@ THE SOFTWARE.
 package markdown
 
 import (
-	"fmt"
 	"strings"
+	"unicode"
 
+	"golang.org/x/text/width"
+
+	"github.com/checkov-docs/checkov-docs/internal/config"
 	"github.com/checkov-docs/checkov-docs/internal/logger"
+	"github.com/checkov-docs/checkov-docs/internal/models"
+	"github.com/checkov-docs/checkov-docs/internal/table"
 )
 
-// WriteTable returns a markdown table with arguments headers and rows
-// revive:disable:unhandled-error ignore regex pattern in golangci-lint does not work
+// WriteTable returns a markdown table with arguments headers and rows.
+// Values are escaped so that they are rendered as plain text in their cell, see EscapeCell.
 func WriteTable(headers []string, rows [][]string, logger *logger.Logger) (string, error) {
+	cells := make([][]table.Cell, len(rows))
+	for i, row := range rows {
+		cells[i] = make([]table.Cell, len(row))
+		for j, value := range row {
+			cells[i][j] = table.Cell{Value: value}
+		}
+	}
+
+	return WriteCells(headers, cells, logger)
+}
+
+// WriteCells returns a markdown table with arguments headers and rows.
+// Cells are rendered as inline code or as escaped plain text, see FormatCell.
+func WriteCells(headers []string, rows [][]table.Cell, logger *logger.Logger) (string, error) {
+	escapedHeaders := make([]string, len(headers))
+	for i, header := range headers {
+		escapedHeaders[i] = EscapeCell(header)
+	}
+	formattedRows := make([][]string, len(rows))
+	for i, row := range rows {
+		formattedRows[i] = make([]string, len(row))
+		for j, cell := range row {
+			formattedRows[i][j] = FormatCell(cell)
+		}
+	}
+
+	return writeTable(escapedHeaders, formattedRows, logger)
+}
+
+// writeTable returns a markdown table with arguments headers and rows, which are already formatted
+// revive:disable:unhandled-error ignore regex pattern in golangci-lint does not work
+func writeTable(headers []string, rows [][]string, logger *logger.Logger) (string, error) {
 	logger.Info("create markdown table")
 	var sb strings.Builder
 
@@ -42,7 +79,7 @@ func WriteTable(headers []string, rows [][]string, logger *logger.Logger) (strin
 	// Create the header row
 	sb.WriteString("|")
 	for i, header := range headers {
-		_, err := sb.WriteString(fmt.Sprintf(" %-*s |", columnLengths[i], header))
+		_, err := sb.WriteString(" " + pad(header, columnLengths[i]) + " |")
 		if err != nil {
 			logger.Error("failed to write header row", err.Error())
 			return "", err
@@ -70,7 +107,7 @@ func WriteTable(headers []string, rows [][]string, logger *logger.Logger) (strin
 	for i, row := range rows {
 		sb.WriteString("|")
 		for i, value := range row {
-			_, err := sb.WriteString(fmt.Sprintf(" %-*s |", columnLengths[i], value))
+			_, err := sb.WriteString(" " + pad(value, columnLengths[i]) + " |")
 			if err != nil {
 				logger.Error("failed to write data row", err.Error())
 				return "", err
@@ -87,19 +124,202 @@ func WriteTable(headers []string, rows [][]string, logger *logger.Logger) (strin
 	return sb.String(), nil
 }
 
-// getColumnLengths returns the length of each column by comparing the maximum length
+// WriteList returns a markdown list with an item for each of `rows`. The first cell of a row is the item,
+// the other cells are nested items prefixed with their header, empty cells are omitted.
+// revive:disable:unhandled-error writing to a strings.Builder doesn't fail
+func WriteList(headers []string, rows [][]table.Cell, logger *logger.Logger) (string, error) {
+	logger.Info("create markdown list")
+	var sb strings.Builder
+
+	for i, row := range rows {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		item := config.GroupTitleEmpty
+		if len(row) > 0 && row[0].Value != "" {
+			item = escapeLineStart(FormatCell(row[0]))
+		}
+		sb.WriteString("- " + item)
+		for j := 1; j < len(row) && j < len(headers); j++ {
+			if row[j].Value == "" {
+				continue
+			}
+			sb.WriteString("\n  - " + EscapeCell(headers[j]) + ": " + FormatCell(row[j]))
+		}
+	}
+	logger.Debug("added list items")
+
+	return sb.String(), nil
+}
+
+// revive:enable:unhandled-error
+
+// escapeLineStart returns `s` with a backslash before the characters that would start a block,
+// e.g. a nested list or a heading, at the start of a list item
+func escapeLineStart(s string) string {
+	if s == "" {
+		return s
+	}
+	if strings.ContainsRune("-+*#>=", rune(s[0])) {
+		return `\` + s
+	}
+	// ordered list markers, e.g. `1.` or `1)`
+	i := 0
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+	if i > 0 && i < len(s) && (s[i] == '.' || s[i] == ')') {
+		return s[:i] + `\` + s[i:]
+	}
+	return s
+}
+
+// WriteHeading returns a markdown heading of `level` with `text`
+func WriteHeading(level int, text string) string {
+	return strings.Repeat("#", level) + " " + text
+}
+
+// WriteGroup returns `content` below a sub-heading with `title`, rendered as inline code if possible
+func WriteGroup(title, content string) string {
+	switch {
+	case title == "":
+		title = config.GroupTitleEmpty
+	case strings.Contains(title, "`"):
+		title = Escape(title)
+	default:
+		title = "`" + title + "`"
+	}
+	return WriteHeading(config.GroupHeadingLevel, title) + "\n\n" + content
+}
+
+// WriteSummary returns a markdown table with a single row containing the totals in `summary`
+func WriteSummary(headers []string, summary *models.Summary, logger *logger.Logger) (string, error) {
+	logger.Info("create markdown summary")
+
+	return WriteCells(headers, [][]table.Cell{table.SummaryRow(summary)}, logger)
+}
+
+// cellEscaper escapes the characters that break a table cell or are rendered as HTML,
+// new lines are converted to line breaks
+var cellEscaper = strings.NewReplacer(
+	"|", `\|`,
+	"`", "\\`",
+	"&", "&amp;",
+	"<", "&lt;",
+	">", "&gt;",
+	"\r\n", "<br>",
+	"\n", "<br>",
+	"\r", "<br>",
+)
+
+// newLineReplacer replaces new lines with spaces, code spans can't contain line breaks
+var newLineReplacer = strings.NewReplacer("\r\n", " ", "\n", " ", "\r", " ")
+
+// urlEscaper escapes the characters that end a link destination or break a table cell
+var urlEscaper = strings.NewReplacer(
+	" ", "%20",
+	"(", "%28",
+	")", "%29",
+	"<", "%3C",
+	">", "%3E",
+	"|", "%7C",
+	"`", "%60",
+)
+
+// linkTextEscaper escapes the characters that end the text of a link
+var linkTextEscaper = strings.NewReplacer("[", `\[`, "]", `\]`)
+
+// FormatCell returns the content of `cell` formatted for a markdown table cell, as a link if it has a URL.
+// Values containing HTML comments are never rendered as inline code, where they can't be escaped,
+// so that they can't be mistaken for the comment tags of the generated section.
+func FormatCell(cell table.Cell) string {
+	code := cell.Code && !strings.Contains(cell.Value, "<!--") && !strings.Contains(cell.Value, "-->")
+	var text string
+	switch {
+	case code:
+		text = CodeCell(cell.Value)
+	case cell.URL != "":
+		text = linkTextEscaper.Replace(EscapeCell(cell.Value))
+	default:
+		text = EscapeCell(cell.Value)
+	}
+
+	if cell.URL == "" || text == "" {
+		return text
+	}
+	return "[" + text + "](" + urlEscaper.Replace(cell.URL) + ")"
+}
+
+// EscapeCell returns `s` escaped to be rendered as plain text in a markdown table cell:
+// pipes and backticks are escaped, HTML is neutralized and new lines are converted to `<br>`
+func EscapeCell(s string) string {
+	return cellEscaper.Replace(s)
+}
+
+// CodeCell returns `s` as inline code in a markdown table cell.
+// The code span is delimited by more backticks than `s` contains in a row,
+// pipes are escaped and new lines are replaced with spaces.
+func CodeCell(s string) string {
+	if s == "" {
+		return ""
+	}
+	s = strings.ReplaceAll(newLineReplacer.Replace(s), "|", `\|`)
+
+	// find the longest sequence of backticks in the value
+	longest, current := 0, 0
+	for _, r := range s {
+		if r != '`' {
+			current = 0
+			continue
+		}
+		current++
+		if current > longest {
+			longest = current
+		}
+	}
+	fence := strings.Repeat("`", longest+1)
+
+	// pad the value if it would otherwise be merged with the fence or stripped by the renderer
+	if strings.HasPrefix(s, "`") || strings.HasSuffix(s, "`") ||
+		(strings.HasPrefix(s, " ") && strings.HasSuffix(s, " ") && strings.TrimSpace(s) != "") {
+		s = " " + s + " "
+	}
+
+	return fence + s + fence
+}
+
+// escaper escapes the characters that have a meaning in inline markdown
+var escaper = strings.NewReplacer(
+	`\`, `\\`,
+	"`", "\\`",
+	"*", `\*`,
+	"_", `\_`,
+	"[", `\[`,
+	"]", `\]`,
+	"<", `\<`,
+	">", `\>`,
+	"|", `\|`,
+)
+
+// Escape returns `s` with the characters that have a meaning in inline markdown escaped,
+// so that it is rendered as plain text
+func Escape(s string) string {
+	return escaper.Replace(s)
+}
+
+// getColumnLengths returns the length of each column by comparing the maximum display width
 // in each element of `headers` and `rows`.
 func getColumnLengths(headers []string, rows [][]string) []int {
 	columnLengths := make([]int, len(headers))
 	for i, header := range headers {
-		length := len(header)
+		length := DisplayWidth(header)
 		if length > columnLengths[i] {
 			columnLengths[i] = length
 		}
 	}
 	for _, row := range rows {
 		for i, value := range row {
-			length := len(value)
+			length := DisplayWidth(value)
 			if length > columnLengths[i] {
 				columnLengths[i] = length
 			}
@@ -108,3 +328,40 @@ func getColumnLengths(headers []string, rows [][]string) []int {
 
 	return columnLengths
 }
+
+// DisplayWidth returns the number of columns `s` takes in a monospaced font:
+// East Asian wide and fullwidth characters, including most emoji, take two columns,
+// combining marks and zero-width characters take none and other characters take one.
+func DisplayWidth(s string) int {
+	w := 0
+	for _, r := range s {
+		w += runeWidth(r)
+	}
+	return w
+}
+
+// runeWidth returns the number of columns `r` takes in a monospaced font
+func runeWidth(r rune) int {
+	switch {
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf) || unicode.Is(unicode.Variation_Selector, r):
+		return 0
+	case r < 0x20 || r == 0x7f:
+		// control characters
+		return 0
+	}
+
+	switch width.LookupRune(r).Kind() {
+	case width.EastAsianWide, width.EastAsianFullwidth:
+		return 2
+	default:
+		return 1
+	}
+}
+
+// pad returns `s` followed by spaces up to the display width `n`
+func pad(s string, n int) string {
+	if w := DisplayWidth(s); w < n {
+		return s + strings.Repeat(" ", n-w)
+	}
+	return s
+}
@@ -31,6 +31,8 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/checkov-docs/checkov-docs/internal/logger"
+	"github.com/checkov-docs/checkov-docs/internal/models"
+	"github.com/checkov-docs/checkov-docs/internal/table"
 )
 
 func TestWriteTable(t *testing.T) {
@@ -56,6 +58,186 @@ func TestWriteTable(t *testing.T) {
 	assert.Equal(strings.TrimSpace(expected), strings.TrimSpace(output))
 }
 
+func TestWriteSummary(t *testing.T) {
+	assert := assert.New(t)
+	logger := logger.NewMockLogger(&bytes.Buffer{})
+
+	// Prepare test data
+	headers := []string{"Passed", "Failed", "Skipped", "Parsing Errors", "Resources", "Checkov Version"}
+	summary := &models.Summary{Passed: 12, Failed: 2, Skipped: 1, ResourceCount: 5, CheckovVersion: "2.3.296"}
+	expected := `
+| Passed | Failed | Skipped | Parsing Errors | Resources | Checkov Version |
+|--------|--------|---------|----------------|-----------|-----------------|
+| 12     | 2      | 1       | 0              | 5         | 2.3.296         |
+`
+
+	// Test writing summary
+	output, err := WriteSummary(headers, summary, logger)
+	assert.Nil(err, "unexpected error writing summary", err)
+	assert.Equal(strings.TrimSpace(expected), strings.TrimSpace(output))
+}
+
+func TestEscape(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("CKV\\_AWS\\_18", Escape("CKV_AWS_18"))
+	assert.Equal("a \\| b \\<br\\> \\[x\\]\\*", Escape("a | b <br> [x]*"))
+	assert.Equal("\\\\ \\`code\\`", Escape("\\ `code`"))
+}
+
+func TestEscapeCell(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		value    string
+		expected string
+	}{
+		{"plain text", "plain text"},
+		{"a | b", `a \| b`},
+		{"line 1\nline 2\r\nline 3\rline 4", "line 1<br>line 2<br>line 3<br>line 4"},
+		{"use `code`", "use \\`code\\`"},
+		{"<b>bold</b> & <!-- comment -->", "&lt;b&gt;bold&lt;/b&gt; &amp; &lt;!-- comment --&gt;"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(tt.expected, EscapeCell(tt.value), "unexpected escaped value for %q", tt.value)
+	}
+}
+
+func TestCodeCell(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		value    string
+		expected string
+	}{
+		{"", ""},
+		{"plain text", "`plain text`"},
+		{"a | b", "`a \\| b`"},
+		{"line 1\nline 2", "`line 1 line 2`"},
+		{"<b>bold</b>", "`<b>bold</b>`"},
+		{"use `code`", "`` use `code` ``"},
+		{"``double``", "``` ``double`` ```"},
+		{" padded ", "`  padded  `"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(tt.expected, CodeCell(tt.value), "unexpected code span for %q", tt.value)
+	}
+}
+
+func TestFormatCell(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("a \\| b", FormatCell(table.Cell{Value: "a | b"}))
+	assert.Equal("`a \\| b`", FormatCell(table.Cell{Value: "a | b", Code: true}))
+
+	// Test links, brackets of the text and characters ending the URL are escaped
+	assert.Equal(`[CKV_AWS_18 \[draft\]](https://example.com/a%20b%28c%29)`, FormatCell(table.Cell{Value: "CKV_AWS_18 [draft]", URL: "https://example.com/a b(c)"}))
+	assert.Equal("[`/main.tf`](https://example.com/main.tf#L1-L3)", FormatCell(table.Cell{Value: "/main.tf", Code: true, URL: "https://example.com/main.tf#L1-L3"}))
+	assert.Equal("", FormatCell(table.Cell{URL: "https://example.com"}))
+
+	// Test HTML comments are always escaped
+	assert.Equal("&lt;!-- END_CHECKOV_DOCS --&gt;", FormatCell(table.Cell{Value: "<!-- END_CHECKOV_DOCS -->", Code: true}))
+}
+
+func TestWriteCells(t *testing.T) {
+	assert := assert.New(t)
+	logger := logger.NewMockLogger(&bytes.Buffer{})
+
+	// Prepare test data
+	headers := []string{"Check ID", "Reason"}
+	rows := [][]table.Cell{
+		{{Value: "CKV_AWS_18"}, {Value: "logs | metrics", Code: true}},
+		{{Value: "CKV_AWS_21"}, {Value: "<i>not</i> needed"}},
+	}
+	expected := `
+| Check ID   | Reason                        |
+|------------|-------------------------------|
+| CKV_AWS_18 | ` + "`logs \\| metrics`" + `             |
+| CKV_AWS_21 | &lt;i&gt;not&lt;/i&gt; needed |
+`
+
+	// Test writing table with formatted cells
+	output, err := WriteCells(headers, rows, logger)
+	assert.Nil(err, "unexpected error writing table", err)
+	assert.Equal(strings.TrimSpace(expected), strings.TrimSpace(output))
+}
+
+func TestWriteList(t *testing.T) {
+	assert := assert.New(t)
+	logger := logger.NewMockLogger(&bytes.Buffer{})
+
+	// Prepare test data
+	headers := []string{"Check ID", "Reason", "Severity"}
+	rows := [][]table.Cell{
+		{{Value: "CKV_AWS_18", URL: "https://example.com"}, {Value: "logs | metrics", Code: true}, {Value: "HIGH"}},
+		{{Value: "1. not a list"}, {Value: "<i>not</i> needed"}, {}},
+		{{}, {Value: "# not a heading"}, {}},
+	}
+	expected := `
+- [CKV_AWS_18](https://example.com)
+  - Reason: ` + "`logs \\| metrics`" + `
+  - Severity: HIGH
+- 1\. not a list
+  - Reason: &lt;i&gt;not&lt;/i&gt; needed
+- (none)
+  - Reason: # not a heading
+`
+
+	// Test writing list with formatted cells
+	output, err := WriteList(headers, rows, logger)
+	assert.Nil(err, "unexpected error writing list", err)
+	assert.Equal(strings.TrimSpace(expected), output)
+	assert.Equal(`\- item`, escapeLineStart("- item"))
+}
+
+func TestDisplayWidth(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		value    string
+		expected int
+	}{
+		{"", 0},
+		{"hello", 5},
+		{"für Übergänge", 13},
+		{"アクセス", 8},
+		{"日本語 text", 11},
+		{"ｆｕｌｌ", 8},
+		{"rocket 🚀", 9},
+		{"cafe\u0301", 4},
+		{"zero\u200bwidth", 9},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(tt.expected, DisplayWidth(tt.value), "unexpected display width for %q", tt.value)
+	}
+}
+
+func TestWriteTable_Unicode(t *testing.T) {
+	assert := assert.New(t)
+	logger := logger.NewMockLogger(&bytes.Buffer{})
+
+	// Prepare test data
+	headers := []string{"Name", "Reason"}
+	rows := [][]string{
+		{"日本", "理由"},
+		{"Jürgen", "ok 👍"},
+	}
+	expected := `
+| Name   | Reason |
+|--------|--------|
+| 日本   | 理由   |
+| Jürgen | ok 👍  |
+`
+
+	// Test writing table aligned by display width
+	output, err := WriteTable(headers, rows, logger)
+	assert.Nil(err, "unexpected error writing table", err)
+	assert.Equal(strings.TrimSpace(expected), strings.TrimSpace(output))
+}
+
 func TestGetColumnLengths(t *testing.T) {
 	assert := assert.New(t)
 
@@ -22,28 +22,257 @@ THE SOFTWARE.
 
 package models
 
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
 // CheckovResults is a struct unmarshalled from a JSON-formatted checkov output
 type CheckovResults struct {
-	Results *Results `json:"results"`
+	CheckType string   `json:"check_type"`
+	Results   *Results `json:"results"`
+	Summary   *Summary `json:"summary"`
+}
+
+// Summary is a struct unmarshalled from a JSON-formatted checkov output
+type Summary struct {
+	Passed         int    `json:"passed"`
+	Failed         int    `json:"failed"`
+	Skipped        int    `json:"skipped"`
+	ParsingErrors  int    `json:"parsing_errors"`
+	ResourceCount  int    `json:"resource_count"`
+	CheckovVersion string `json:"checkov_version"`
 }
 
 // Results is a struct unmarshalled from a JSON-formatted checkov output
 type Results struct {
+	PassedChecks  []*Check `json:"passed_checks"`
+	FailedChecks  []*Check `json:"failed_checks"`
 	SkippedChecks []*Check `json:"skipped_checks"`
 }
 
 // Check is a struct unmarshalled from a JSON-formatted checkov output
 type Check struct {
-	FilePath    string       `json:"file_path"`
-	CheckID     string       `json:"check_id"`
-	CheckName   string       `json:"check_name"`
-	Resource    string       `json:"resource"`
-	Guideline   string       `json:"guideline"`
-	CheckResult *CheckResult `json:"check_result"`
+	FilePath        string                 `json:"file_path"`
+	FileAbsPath     string                 `json:"file_abs_path"`
+	RepoFilePath    string                 `json:"repo_file_path"`
+	FileLineRange   []int                  `json:"file_line_range"`
+	CheckID         string                 `json:"check_id"`
+	BcCheckID       string                 `json:"bc_check_id"`
+	CheckName       string                 `json:"check_name"`
+	CheckClass      string                 `json:"check_class"`
+	Resource        string                 `json:"resource"`
+	ResourceAddress string                 `json:"resource_address"`
+	Severity        string                 `json:"severity"`
+	Guideline       string                 `json:"guideline"`
+	CheckResult     *CheckResult           `json:"check_result"`
+	CodeBlock       []*CodeLine            `json:"code_block"`
+	Details         []string               `json:"details"`
+	Evaluations     map[string]interface{} `json:"evaluations"`
+	Benchmarks      map[string]interface{} `json:"benchmarks"`
+	EntityTags      map[string]interface{} `json:"entity_tags"`
+	// CheckType is the framework of the report the check was found in, e.g. terraform
+	CheckType string `json:"check_type,omitempty"`
+	// Source is the name of the input file the check was read from
+	Source string `json:"source,omitempty"`
 }
 
 // CheckResult is a struct unmarshalled from a JSON-formatted checkov output
 type CheckResult struct {
-	Result          string `json:"result"`
-	SuppressComment string `json:"suppress_comment"`
+	Result          string   `json:"result"`
+	SuppressComment string   `json:"suppress_comment"`
+	EvaluatedKeys   []string `json:"evaluated_keys"`
+}
+
+// CodeLine is a line of the code block of a check, checkov outputs it as a `[number, code]` array
+type CodeLine struct {
+	Line int
+	Code string
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface
+func (c *CodeLine) UnmarshalJSON(data []byte) error {
+	var line []json.RawMessage
+	if err := json.Unmarshal(data, &line); err != nil {
+		return err
+	}
+	if len(line) != 2 {
+		return fmt.Errorf("code line has %d elements, expected 2", len(line))
+	}
+	if err := json.Unmarshal(line[0], &c.Line); err != nil {
+		return err
+	}
+	return json.Unmarshal(line[1], &c.Code)
+}
+
+// MarshalJSON implements the json.Marshaler interface
+func (c CodeLine) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]interface{}{c.Line, c.Code})
+}
+
+// StartLine returns the first line of the check in the file, 0 if unknown
+func (c *Check) StartLine() int {
+	if len(c.FileLineRange) > 0 {
+		return c.FileLineRange[0]
+	}
+	return 0
+}
+
+// EndLine returns the last line of the check in the file, 0 if unknown
+func (c *Check) EndLine() int {
+	if len(c.FileLineRange) > 1 {
+		return c.FileLineRange[1]
+	}
+	return c.StartLine()
+}
+
+// ResourceType returns the type of the resource, e.g. `aws_s3_bucket` for `aws_s3_bucket.example`
+// or `module.bucket.aws_s3_bucket.example`
+func (c *Check) ResourceType() string {
+	parts := strings.Split(c.Resource, ".")
+	// skip module calls of nested module addresses
+	for len(parts) > 2 && parts[0] == "module" {
+		parts = parts[2:]
+	}
+	return parts[0]
+}
+
+// Field returns the string representation of the field `name` of the check.
+// See Fields for the valid names, aliases are resolved with FieldName.
+func (c *Check) Field(name string) string {
+	getter, ok := fieldGetters[FieldName(name)]
+	if !ok {
+		return ""
+	}
+	return getter(c)
+}
+
+// Fields stores the names of the check fields that can be selected as columns, used for filtering and sorting
+var Fields = []string{
+	"file_path", "file_abs_path", "repo_file_path", "file_line_range", "start_line", "end_line",
+	"check_id", "bc_check_id", "check_name", "check_class", "check_type",
+	"resource", "resource_type", "resource_address", "severity", "guideline",
+	"result", "suppress_comment", "evaluated_keys", "code_block", "details", "evaluations", "benchmarks", "entity_tags", "source",
+}
+
+// fieldAliases stores short names of check fields
+var fieldAliases = map[string]string{
+	"file":      "file_path",
+	"line":      "file_line_range",
+	"framework": "check_type",
+	"reason":    "suppress_comment",
+}
+
+// FieldName returns the name of the check field `name` refers to, resolving aliases.
+// It returns an empty string if `name` isn't a valid field.
+func FieldName(name string) string {
+	name = strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), "-", "_")
+	if alias, ok := fieldAliases[name]; ok {
+		name = alias
+	}
+	if _, ok := fieldGetters[name]; !ok {
+		return ""
+	}
+	return name
+}
+
+// fieldGetters stores the functions returning the string representation of each check field
+var fieldGetters = map[string]func(*Check) string{
+	"file_path":      func(c *Check) string { return c.FilePath },
+	"file_abs_path":  func(c *Check) string { return c.FileAbsPath },
+	"repo_file_path": func(c *Check) string { return c.RepoFilePath },
+	"file_line_range": func(c *Check) string {
+		if len(c.FileLineRange) == 0 {
+			return ""
+		}
+		return fmt.Sprintf("%d-%d", c.StartLine(), c.EndLine())
+	},
+	"start_line":       func(c *Check) string { return formatLine(c.StartLine()) },
+	"end_line":         func(c *Check) string { return formatLine(c.EndLine()) },
+	"check_id":         func(c *Check) string { return c.CheckID },
+	"bc_check_id":      func(c *Check) string { return c.BcCheckID },
+	"check_name":       func(c *Check) string { return c.CheckName },
+	"check_class":      func(c *Check) string { return c.CheckClass },
+	"check_type":       func(c *Check) string { return c.CheckType },
+	"resource":         func(c *Check) string { return c.Resource },
+	"resource_type":    func(c *Check) string { return c.ResourceType() },
+	"resource_address": func(c *Check) string { return c.ResourceAddress },
+	"severity":         func(c *Check) string { return c.Severity },
+	"guideline":        func(c *Check) string { return c.Guideline },
+	"result": func(c *Check) string {
+		if c.CheckResult == nil {
+			return ""
+		}
+		return c.CheckResult.Result
+	},
+	"suppress_comment": func(c *Check) string {
+		if c.CheckResult == nil {
+			return ""
+		}
+		return c.CheckResult.SuppressComment
+	},
+	"evaluated_keys": func(c *Check) string {
+		if c.CheckResult == nil {
+			return ""
+		}
+		return strings.Join(c.CheckResult.EvaluatedKeys, ", ")
+	},
+	"code_block": func(c *Check) string {
+		lines := make([]string, len(c.CodeBlock))
+		for i, line := range c.CodeBlock {
+			lines[i] = strings.TrimRight(line.Code, "\n")
+		}
+		return strings.Join(lines, "\n")
+	},
+	"details":     func(c *Check) string { return strings.Join(c.Details, ", ") },
+	"evaluations": func(c *Check) string { return formatMap(c.Evaluations) },
+	"benchmarks":  func(c *Check) string { return formatMap(c.Benchmarks) },
+	"entity_tags": func(c *Check) string { return formatMap(c.EntityTags) },
+	"source":      func(c *Check) string { return c.Source },
+}
+
+// formatLine returns the string representation of a line number, empty if unknown
+func formatLine(line int) string {
+	if line == 0 {
+		return ""
+	}
+	return strconv.Itoa(line)
+}
+
+// formatMap returns the `key=value` pairs of `m` sorted by key, see formatValue
+func formatMap(m map[string]interface{}) string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, len(keys))
+	for i, key := range keys {
+		pairs[i] = key + "=" + formatValue(m[key])
+	}
+
+	return strings.Join(pairs, ", ")
+}
+
+// formatValue returns the string representation of a decoded JSON value, lists are separated by spaces
+// and nested objects are written as `{key=value, ...}` sorted by key, so that the output is deterministic
+func formatValue(value interface{}) string {
+	switch value := value.(type) {
+	case []interface{}:
+		values := make([]string, len(value))
+		for i, v := range value {
+			values[i] = formatValue(v)
+		}
+		return strings.Join(values, " ")
+	case map[string]interface{}:
+		return "{" + formatMap(value) + "}"
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(value)
+	}
 }
@@ -0,0 +1,112 @@
+/*
+Copyright © 2023 The checkov-docs Authors
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package models
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCodeLine_JSON(t *testing.T) {
+	assert := assert.New(t)
+
+	// Test decoding code lines stored as [line, code] pairs
+	var lines []*CodeLine
+	err := json.Unmarshal([]byte(`[[1, "resource \"aws_s3_bucket\" \"a\" {\n"], [2, "}\n"]]`), &lines)
+	assert.Nil(err, "unexpected error decoding code lines", err)
+	assert.Equal([]*CodeLine{{Line: 1, Code: "resource \"aws_s3_bucket\" \"a\" {\n"}, {Line: 2, Code: "}\n"}}, lines)
+
+	// Test encoding code lines back to pairs
+	data, err := json.Marshal(lines)
+	assert.Nil(err, "unexpected error encoding code lines", err)
+	assert.Equal(`[[1,"resource \"aws_s3_bucket\" \"a\" {\n"],[2,"}\n"]]`, string(data))
+
+	// Test decoding an invalid code line
+	err = json.Unmarshal([]byte(`[["1", "code"]]`), &lines)
+	assert.NotNil(err, "expected an error for an invalid code line, but got no error")
+}
+
+func TestCheck_ResourceType(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("aws_s3_bucket", (&Check{Resource: "aws_s3_bucket.example"}).ResourceType())
+	assert.Equal("aws_s3_bucket", (&Check{Resource: "module.bucket.aws_s3_bucket.example"}).ResourceType())
+	assert.Equal("", (&Check{}).ResourceType())
+}
+
+func TestFieldName(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("check_id", FieldName("check_id"))
+	assert.Equal("check_id", FieldName("Check-ID"))
+	assert.Equal("file_path", FieldName("file"))
+	assert.Equal("check_type", FieldName("framework"))
+	assert.Equal("suppress_comment", FieldName("reason"))
+	assert.Equal("", FieldName("unknown"))
+
+	// Every listed field must have a getter
+	for _, field := range Fields {
+		assert.Equal(field, FieldName(field))
+	}
+}
+
+func TestCheck_Field(t *testing.T) {
+	assert := assert.New(t)
+
+	check := &Check{
+		FilePath:      "/main.tf",
+		FileLineRange: []int{3, 12},
+		CheckID:       "CKV_AWS_18",
+		Resource:      "aws_s3_bucket.example",
+		CheckResult:   &CheckResult{Result: "SKIPPED", SuppressComment: "access logs are disabled", EvaluatedKeys: []string{"logging", "acl"}},
+		CodeBlock:     []*CodeLine{{Line: 3, Code: "resource {\n"}, {Line: 4, Code: "}\n"}},
+		EntityTags:    map[string]interface{}{"team": "platform", "env": "prod"},
+		Benchmarks:    map[string]interface{}{"CIS AWS V1.2": []interface{}{"2.6"}},
+		Evaluations: map[string]interface{}{
+			"logging": map[string]interface{}{"var_file": "/vars.tf", "value": []interface{}{"true"}, "definitions": []interface{}{map[string]interface{}{"line": 2.0, "file": "/vars.tf"}}},
+			"acl":     nil,
+		},
+	}
+
+	assert.Equal("/main.tf", check.Field("file"))
+	assert.Equal("3-12", check.Field("line"))
+	assert.Equal("3", check.Field("start_line"))
+	assert.Equal("12", check.Field("end_line"))
+	assert.Equal("aws_s3_bucket", check.Field("resource_type"))
+	assert.Equal("SKIPPED", check.Field("result"))
+	assert.Equal("access logs are disabled", check.Field("reason"))
+	assert.Equal("logging, acl", check.Field("evaluated_keys"))
+	assert.Equal("resource {\n}", check.Field("code_block"))
+	assert.Equal("env=prod, team=platform", check.Field("entity_tags"))
+	assert.Equal("CIS AWS V1.2=2.6", check.Field("benchmarks"))
+	assert.Equal("acl=, logging={definitions={file=/vars.tf, line=2}, value=true, var_file=/vars.tf}", check.Field("evaluations"))
+	assert.Equal("", check.Field("unknown"))
+
+	// Test fields of a check without result and line range
+	empty := &Check{}
+	assert.Equal("", empty.Field("line"))
+	assert.Equal("", empty.Field("start_line"))
+	assert.Equal("", empty.Field("reason"))
+}
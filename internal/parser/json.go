@@ -0,0 +1,495 @@
+/*
+Copyright © 2023 The checkov-docs Authors
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package parser
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+
+	"github.com/checkov-docs/checkov-docs/internal/config"
+	"github.com/checkov-docs/checkov-docs/internal/models"
+)
+
+// Names of the sections in checkov results
+const (
+	sectionPassed  = "passed_checks"
+	sectionFailed  = "failed_checks"
+	sectionSkipped = "skipped_checks"
+)
+
+// summaryKeys stores the keys of a summary that checkov emits at the top level
+// of its output when no resources are found
+var summaryKeys = map[string]bool{
+	"passed":          true,
+	"failed":          true,
+	"skipped":         true,
+	"parsing_errors":  true,
+	"resource_count":  true,
+	"checkov_version": true,
+}
+
+// skipValue discards a JSON value without unmarshalling it
+type skipValue struct{}
+
+// UnmarshalJSON implements the json.Unmarshaler interface
+func (*skipValue) UnmarshalJSON([]byte) error {
+	return nil
+}
+
+// checkField is a field of a check entry.
+// A malformed required field drops the finding, other fields are reported and left empty.
+type checkField struct {
+	name     string
+	required bool
+	target   func(*models.Check) interface{}
+}
+
+// checkFields stores the fields of a check entry, except check_result that holds nested fields
+var checkFields = []checkField{
+	{"file_path", true, func(c *models.Check) interface{} { return &c.FilePath }},
+	{"file_abs_path", false, func(c *models.Check) interface{} { return &c.FileAbsPath }},
+	{"repo_file_path", false, func(c *models.Check) interface{} { return &c.RepoFilePath }},
+	{"file_line_range", false, func(c *models.Check) interface{} { return &c.FileLineRange }},
+	{"check_id", true, func(c *models.Check) interface{} { return &c.CheckID }},
+	{"bc_check_id", false, func(c *models.Check) interface{} { return &c.BcCheckID }},
+	{"check_name", true, func(c *models.Check) interface{} { return &c.CheckName }},
+	{"check_class", false, func(c *models.Check) interface{} { return &c.CheckClass }},
+	{"resource", true, func(c *models.Check) interface{} { return &c.Resource }},
+	{"resource_address", false, func(c *models.Check) interface{} { return &c.ResourceAddress }},
+	{"severity", false, func(c *models.Check) interface{} { return &c.Severity }},
+	{"guideline", true, func(c *models.Check) interface{} { return &c.Guideline }},
+	{"code_block", false, func(c *models.Check) interface{} { return &c.CodeBlock }},
+	{"details", false, func(c *models.Check) interface{} { return &c.Details }},
+	{"evaluations", false, func(c *models.Check) interface{} { return &c.Evaluations }},
+	{"benchmarks", false, func(c *models.Check) interface{} { return &c.Benchmarks }},
+	{"entity_tags", false, func(c *models.Check) interface{} { return &c.EntityTags }},
+	{"check_type", false, func(c *models.Check) interface{} { return &c.CheckType }},
+	{"source", false, func(c *models.Check) interface{} { return &c.Source }},
+}
+
+// checkResultEntry is the check_result of a check entry, evaluated keys are decoded leniently
+type checkResultEntry struct {
+	Result          string          `json:"result"`
+	SuppressComment string          `json:"suppress_comment"`
+	EvaluatedKeys   json.RawMessage `json:"evaluated_keys"`
+}
+
+// jsonDecoder walks the token stream of JSON-formatted checkov results.
+// Only the sections in `sections` are unmarshalled, entries of other sections
+// are discarded one by one so that memory usage is bounded by the largest entry
+// instead of the size of the report.
+type jsonDecoder struct {
+	dec        *json.Decoder
+	sections   map[string]bool
+	codeBlocks bool
+	warnings   []Warning
+}
+
+// ReadJSON returns checkov results decoded from a JSON-formatted stream.
+//
+// Checkov returns a single result object when scanning one framework and an array
+// of result objects when scanning several frameworks at once, both forms are accepted.
+// Findings are merged and each one keeps the `check_type` of the report it was found in.
+//
+// Only checks matching `opts.ResultTypes` are decoded, all result types are decoded if none is given.
+// Code blocks are dropped unless `opts.CodeBlocks` is set, so that they aren't kept in memory.
+// Missing sections, e.g. a report with only a `summary` block, are treated as empty.
+// Malformed entries are skipped and returned as warnings, an error is only returned
+// if the stream is not valid JSON.
+func ReadJSON(r io.Reader, opts ReadOptions) (*models.CheckovResults, []Warning, error) {
+	d := &jsonDecoder{dec: json.NewDecoder(r), sections: getSections(opts.ResultTypes), codeBlocks: opts.CodeBlocks}
+
+	tok, err := d.dec.Token()
+	if errors.Is(err, io.EOF) {
+		return nil, nil, errors.New("checkov results are empty")
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var reports []*models.CheckovResults
+	switch tok {
+	case json.Delim('['):
+		for i := 0; d.dec.More(); i++ {
+			report, reportErr := d.decodeArrayElement(i)
+			if reportErr != nil {
+				return nil, nil, reportErr
+			}
+			if report != nil {
+				reports = append(reports, report)
+			}
+		}
+		if _, err = d.dec.Token(); err != nil {
+			return nil, nil, err
+		}
+	case json.Delim('{'):
+		report, reportErr := d.decodeReport(0)
+		if reportErr != nil {
+			return nil, nil, reportErr
+		}
+		reports = append(reports, report)
+	default:
+		return nil, nil, errors.New("checkov results are not a JSON object or array")
+	}
+
+	if _, err = d.dec.Token(); !errors.Is(err, io.EOF) {
+		return nil, nil, errors.New("checkov results are followed by unexpected data")
+	}
+
+	return Merge(reports...), d.warnings, nil
+}
+
+// getSections returns the names of the sections holding checks of `resultTypes`
+func getSections(resultTypes []string) map[string]bool {
+	sections := make(map[string]bool, len(resultTypes))
+	for _, resultType := range resultTypes {
+		switch resultType {
+		case config.ResultTypePassed:
+			sections[sectionPassed] = true
+		case config.ResultTypeFailed:
+			sections[sectionFailed] = true
+		case "", config.ResultTypeSkipped:
+			sections[sectionSkipped] = true
+		}
+	}
+	if len(sections) == 0 {
+		return map[string]bool{sectionPassed: true, sectionFailed: true, sectionSkipped: true}
+	}
+	return sections
+}
+
+// decodeArrayElement returns the report at `index` of an array output, or nil if it isn't an object
+func (d *jsonDecoder) decodeArrayElement(index int) (*models.CheckovResults, error) {
+	tok, err := d.dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	if tok == json.Delim('{') {
+		return d.decodeReport(index)
+	}
+
+	if err = d.skipRest(tok); err != nil {
+		return nil, err
+	}
+	d.warn(index, "", -1, "report is not an object")
+	return nil, nil
+}
+
+// decodeReport returns a single checkov result object, the opening brace is already consumed
+func (d *jsonDecoder) decodeReport(index int) (*models.CheckovResults, error) {
+	report := &models.CheckovResults{Results: &models.Results{}}
+	bareSummary := make(map[string]json.RawMessage)
+
+	for d.dec.More() {
+		key, err := d.key()
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case key == "check_type":
+			err = d.decodeValue(&report.CheckType, index, "check_type", -1)
+		case key == "summary":
+			err = d.decodeValue(&report.Summary, index, "summary", -1)
+		case key == "results":
+			err = d.decodeResults(report.Results, index)
+		case summaryKeys[key]:
+			// checkov emits a bare summary when no resources are found
+			var raw json.RawMessage
+			err = d.dec.Decode(&raw)
+			bareSummary[key] = raw
+		default:
+			err = d.dec.Decode(&skipValue{})
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := d.dec.Token(); err != nil {
+		return nil, err
+	}
+
+	if report.Summary == nil && len(bareSummary) > 0 {
+		data, err := json.Marshal(bareSummary)
+		if err != nil {
+			return nil, err
+		}
+		report.Summary = &models.Summary{}
+		if err = json.Unmarshal(data, report.Summary); err != nil {
+			d.warn(index, "summary", -1, err.Error())
+			report.Summary = nil
+		}
+	}
+
+	return report, nil
+}
+
+// decodeResults decodes the `results` object of a report into `results`
+func (d *jsonDecoder) decodeResults(results *models.Results, index int) error {
+	tok, err := d.dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok == nil {
+		return nil
+	}
+	if tok != json.Delim('{') {
+		d.warn(index, "results", -1, "results is not an object")
+		return d.skipRest(tok)
+	}
+
+	for d.dec.More() {
+		section, err := d.key()
+		if err != nil {
+			return err
+		}
+
+		switch section {
+		case sectionPassed:
+			results.PassedChecks, err = d.decodeSection(index, section)
+		case sectionFailed:
+			results.FailedChecks, err = d.decodeSection(index, section)
+		case sectionSkipped:
+			results.SkippedChecks, err = d.decodeSection(index, section)
+		default:
+			err = d.dec.Decode(&skipValue{})
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = d.dec.Token()
+	return err
+}
+
+// decodeSection returns the checks of a section in checkov results.
+// Entries of sections that aren't selected are discarded one by one.
+func (d *jsonDecoder) decodeSection(index int, section string) ([]*models.Check, error) {
+	path := "results." + section
+
+	tok, err := d.dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	if tok == nil {
+		return nil, nil
+	}
+	if tok != json.Delim('[') {
+		d.warn(index, path, -1, "section is not an array")
+		return nil, d.skipRest(tok)
+	}
+
+	var checks []*models.Check
+	for i := 0; d.dec.More(); i++ {
+		if !d.sections[section] {
+			if err = d.dec.Decode(&skipValue{}); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		check, decodeErr := d.decodeEntry(index, path, i)
+		if decodeErr != nil {
+			return nil, decodeErr
+		}
+		if check == nil {
+			continue
+		}
+
+		if !d.codeBlocks {
+			check.CodeBlock = nil
+		}
+		if check.CheckResult == nil {
+			// keep the finding, a missing check result only means there is no suppress comment
+			check.CheckResult = &models.CheckResult{}
+			if section == sectionSkipped {
+				d.warn(index, path, i, "entry has no check_result")
+			}
+		}
+
+		checks = append(checks, check)
+	}
+
+	_, err = d.dec.Token()
+	return checks, err
+}
+
+// decodeEntry returns the check decoded from an entry of a section, or nil if the entry is malformed.
+// Code blocks are skipped token by token unless they are rendered.
+func (d *jsonDecoder) decodeEntry(index int, path string, i int) (*models.Check, error) {
+	tok, err := d.dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	if tok == nil {
+		d.warn(index, path, i, "entry is null")
+		return nil, nil
+	}
+	if tok != json.Delim('{') {
+		d.warn(index, path, i, "entry is not an object")
+		return nil, d.skipRest(tok)
+	}
+
+	fields := make(map[string]json.RawMessage)
+	for d.dec.More() {
+		key, err := d.key()
+		if err != nil {
+			return nil, err
+		}
+
+		if key == "code_block" && !d.codeBlocks {
+			if tok, err = d.dec.Token(); err == nil {
+				err = d.skipRest(tok)
+			}
+		} else {
+			var raw json.RawMessage
+			err = d.dec.Decode(&raw)
+			fields[key] = raw
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err = d.dec.Token(); err != nil {
+		return nil, err
+	}
+
+	return d.buildCheck(index, path, i, fields), nil
+}
+
+// buildCheck returns the check holding the decoded `fields` of an entry, or nil if a required field is malformed.
+// Malformed optional fields are reported and left empty so that the finding is kept.
+func (d *jsonDecoder) buildCheck(index int, path string, i int, fields map[string]json.RawMessage) *models.Check {
+	check := &models.Check{}
+	for _, field := range checkFields {
+		raw, ok := fields[field.name]
+		if !ok {
+			continue
+		}
+		if err := decodeField(raw, field.target(check)); err != nil {
+			d.warn(index, path, i, field.name+": "+err.Error())
+			if field.required {
+				return nil
+			}
+		}
+	}
+
+	raw, ok := fields["check_result"]
+	if !ok {
+		return check
+	}
+	var err error
+	if check.CheckResult, err = d.decodeCheckResult(index, path, i, raw); err != nil {
+		d.warn(index, path, i, "check_result: "+err.Error())
+		return nil
+	}
+
+	return check
+}
+
+// decodeCheckResult returns the check result decoded from `raw`, malformed evaluated keys are reported and left empty
+func (d *jsonDecoder) decodeCheckResult(index int, path string, i int, raw json.RawMessage) (*models.CheckResult, error) {
+	var entry *checkResultEntry
+	if err := json.Unmarshal(raw, &entry); err != nil || entry == nil {
+		return nil, err
+	}
+
+	result := &models.CheckResult{Result: entry.Result, SuppressComment: entry.SuppressComment}
+	if len(entry.EvaluatedKeys) > 0 {
+		if err := decodeField(entry.EvaluatedKeys, &result.EvaluatedKeys); err != nil {
+			d.warn(index, path, i, "check_result.evaluated_keys: "+err.Error())
+		}
+	}
+
+	return result, nil
+}
+
+// decodeField unmarshals `raw` into `target`, a pointer that is left unchanged if `raw` is malformed
+func decodeField(raw json.RawMessage, target interface{}) error {
+	value := reflect.New(reflect.TypeOf(target).Elem())
+	if err := json.Unmarshal(raw, value.Interface()); err != nil {
+		return err
+	}
+	reflect.ValueOf(target).Elem().Set(value.Elem())
+	return nil
+}
+
+// decodeValue decodes the next value into `v`, type errors are reported as warnings
+func (d *jsonDecoder) decodeValue(v interface{}, index int, section string, i int) error {
+	err := d.dec.Decode(v)
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		d.warn(index, section, i, err.Error())
+		return nil
+	}
+
+	return err
+}
+
+// key returns the next object key
+func (d *jsonDecoder) key() (string, error) {
+	tok, err := d.dec.Token()
+	if err != nil {
+		return "", err
+	}
+
+	key, ok := tok.(string)
+	if !ok {
+		return "", fmt.Errorf("unexpected token %v, expected an object key", tok)
+	}
+
+	return key, nil
+}
+
+// skipRest discards the rest of a value whose first token `tok` is already consumed
+func (d *jsonDecoder) skipRest(tok json.Token) error {
+	depth := 0
+	for {
+		switch tok {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+		if depth == 0 {
+			return nil
+		}
+
+		var err error
+		if tok, err = d.dec.Token(); err != nil {
+			return err
+		}
+	}
+}
+
+// warn records a malformed entry
+func (d *jsonDecoder) warn(index int, section string, i int, message string) {
+	d.warnings = append(d.warnings, Warning{Report: index, Section: section, Index: i, Message: message})
+}
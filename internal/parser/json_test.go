@@ -0,0 +1,288 @@
+/*
+Copyright © 2023 The checkov-docs Authors
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package parser
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/checkov-docs/checkov-docs/internal/config"
+	"github.com/checkov-docs/checkov-docs/internal/models"
+)
+
+func TestReadJSON_ResultTypes(t *testing.T) {
+	assert := assert.New(t)
+
+	// Prepare test data
+	data := `{
+		"check_type": "terraform",
+		"url": {"ignored": ["value"]},
+		"results": {
+			"passed_checks": [{"check_id": "CKV_AWS_45", "code_block": [[1, "resource \"aws_lambda_function\" \"example\" {\n"]]}],
+			"failed_checks": [{"check_id": "CKV_AWS_116"}, 42],
+			"skipped_checks": [{"check_id": "CKV_AWS_115", "check_result": {"result": "SKIPPED", "suppress_comment": "hello world"}}],
+			"parsing_errors": ["/broken.tf"]
+		},
+		"summary": {"passed": 1, "failed": 1, "skipped": 1, "checkov_version": "2.3.296"}
+	}`
+
+	// Test decoding only skipped checks, malformed entries of other sections are not reported
+	results, warnings, err := ReadJSON(strings.NewReader(data), ReadOptions{ResultTypes: []string{config.ResultTypeSkipped}})
+	assert.Nil(err, "unexpected error while reading JSON results", err)
+	assert.Empty(warnings)
+	assert.Empty(results.Results.PassedChecks)
+	assert.Empty(results.Results.FailedChecks)
+	assert.Len(results.Results.SkippedChecks, 1)
+	assert.Equal("2.3.296", results.Summary.CheckovVersion)
+
+	// Test decoding passed and failed checks
+	results, warnings, err = ReadJSON(strings.NewReader(data), ReadOptions{ResultTypes: []string{config.ResultTypePassed, config.ResultTypeFailed}})
+	assert.Nil(err, "unexpected error while reading JSON results", err)
+	assert.Len(warnings, 1)
+	assert.Len(results.Results.PassedChecks, 1)
+	assert.Len(results.Results.FailedChecks, 1)
+	assert.Empty(results.Results.SkippedChecks)
+
+	assert.Empty(results.Results.PassedChecks[0].CodeBlock)
+
+	// Test decoding all checks with their code blocks
+	results, _, err = ReadJSON(strings.NewReader(data), ReadOptions{CodeBlocks: true})
+	assert.Nil(err, "unexpected error while reading JSON results", err)
+	assert.Len(results.Results.PassedChecks, 1)
+	assert.Equal([]*models.CodeLine{{Line: 1, Code: "resource \"aws_lambda_function\" \"example\" {\n"}}, results.Results.PassedChecks[0].CodeBlock)
+	assert.Len(results.Results.FailedChecks, 1)
+	assert.Len(results.Results.SkippedChecks, 1)
+}
+
+func TestReadJSON_MalformedFields(t *testing.T) {
+	assert := assert.New(t)
+
+	// Prepare test data
+	data := `{"results": {"failed_checks": [{
+		"check_id": "CKV_AWS_116",
+		"file_line_range": [1, "x"],
+		"code_block": [[1, "x", 3]],
+		"details": [{"a": 1}],
+		"check_result": {"result": "FAILED", "evaluated_keys": "public"}
+	}]}}`
+
+	// Test that malformed fields are reported without losing the finding
+	results, warnings, err := ReadJSON(strings.NewReader(data), ReadOptions{CodeBlocks: true})
+	assert.Nil(err, "unexpected error while reading JSON results", err)
+	assert.Len(results.Results.FailedChecks, 1)
+	check := results.Results.FailedChecks[0]
+	assert.Equal("CKV_AWS_116", check.CheckID)
+	assert.Equal("FAILED", check.CheckResult.Result)
+	assert.Empty(check.FileLineRange)
+	assert.Empty(check.CodeBlock)
+	assert.Empty(check.Details)
+	assert.Empty(check.CheckResult.EvaluatedKeys)
+
+	// Assert that each malformed field is reported, the rest of the message depends on the Go version
+	expected := []string{"file_line_range", "code_block", "details", "check_result.evaluated_keys"}
+	actual := make([]string, len(warnings))
+	for i, warning := range warnings {
+		assert.Equal(0, warning.Index)
+		actual[i] = strings.SplitN(warning.Message, ":", 2)[0]
+	}
+	assert.Equal(expected, actual)
+
+	// Test that code blocks aren't decoded when they aren't rendered
+	_, warnings, err = ReadJSON(strings.NewReader(data), ReadOptions{})
+	assert.Nil(err, "unexpected error while reading JSON results", err)
+	assert.Len(warnings, 3)
+}
+
+func TestReadJSON_Error(t *testing.T) {
+	assert := assert.New(t)
+
+	// Test reading invalid JSON streams
+	for _, data := range []string{
+		``,
+		`"terraform"`,
+		`{"results": {"skipped_checks": [}}`,
+		`{"results": {}} {"results": {}}`,
+		`[{"results": {}}`,
+	} {
+		_, _, err := ReadJSON(strings.NewReader(data), ReadOptions{})
+		assert.NotNil(err, "expected an error while reading %q, but got no error", data)
+	}
+}
+
+func TestRead(t *testing.T) {
+	assert := assert.New(t)
+
+	// Test reading with a detected format from a stream
+	results, _, err := Read(strings.NewReader(`  {"check_type": "terraform", "results": {"skipped_checks": [{"check_id": "CKV_AWS_115"}]}}`), "", ReadOptions{})
+	assert.Nil(err, "unexpected error while reading results", err)
+	assert.Len(results.Results.SkippedChecks, 1)
+
+	results, _, err = Read(strings.NewReader(`{"$schema": "https://json.schemastore.org/sarif-2.1.0.json", "version": "2.1.0", "runs": []}`), config.InputFormatAuto, ReadOptions{})
+	assert.Nil(err, "unexpected error while reading results", err)
+	assert.Empty(results.Results.SkippedChecks)
+
+	// Test reading an empty stream
+	_, _, err = Read(strings.NewReader(""), config.InputFormatAuto, ReadOptions{})
+	assert.NotNil(err, "expected an error while reading an empty stream, but got no error")
+}
+
+// syntheticReport generates a large JSON-formatted checkov report on the fly,
+// so that the report itself doesn't count towards memory usage
+type syntheticReport struct {
+	passed, skipped int
+	i               int
+	buf             bytes.Buffer
+}
+
+// newSyntheticReport returns a reader for a report with `passed` passed checks with
+// a large code block each, and `skipped` skipped checks
+func newSyntheticReport(passed, skipped int) io.Reader {
+	r := &syntheticReport{passed: passed, skipped: skipped}
+	r.buf.WriteString(`{"check_type": "terraform", "results": {"passed_checks": [`)
+	return r
+}
+
+// Read implements the io.Reader interface
+func (r *syntheticReport) Read(p []byte) (int, error) {
+	for r.buf.Len() < len(p) && r.i <= r.passed+r.skipped {
+		r.next()
+	}
+	if r.buf.Len() == 0 {
+		return 0, io.EOF
+	}
+	return r.buf.Read(p)
+}
+
+// next writes the next entry of the report to the buffer
+// revive:disable:unhandled-error writing to a bytes.Buffer doesn't fail
+func (r *syntheticReport) next() {
+	switch {
+	case r.i < r.passed:
+		if r.i > 0 {
+			r.buf.WriteString(",")
+		}
+		fmt.Fprintf(&r.buf, `{"check_id": "CKV_AWS_%d", "check_name": "Ensure something", "check_result": {"result": "PASSED"}, "file_path": "/main%d.tf", "resource": "aws_s3_bucket.b%d", "code_block": [`, r.i%300, r.i, r.i)
+		for line := 0; line < 50; line++ {
+			if line > 0 {
+				r.buf.WriteString(",")
+			}
+			fmt.Fprintf(&r.buf, `[%d, "  attribute_%d = \"%s\"\n"]`, line, line, strings.Repeat("x", 40))
+		}
+		r.buf.WriteString(`]}`)
+	case r.i == r.passed:
+		r.buf.WriteString(`], "failed_checks": [], "skipped_checks": [`)
+	default:
+		j := r.i - r.passed - 1
+		if j > 0 {
+			r.buf.WriteString(",")
+		}
+		fmt.Fprintf(&r.buf, `{"check_id": "CKV_AWS_115", "check_result": {"result": "SKIPPED", "suppress_comment": "reason %d"}, "file_path": "/main%d.tf", "resource": "aws_lambda_function.f%d"}`, j, j, j)
+		if j == r.skipped-1 {
+			r.buf.WriteString(`]}, "summary": {"passed": 0, "failed": 0, "skipped": 0, "checkov_version": "2.3.296"}}`)
+		}
+	}
+	r.i++
+}
+
+// revive:enable:unhandled-error
+
+// peakHeap samples the heap while `f` runs and returns the peak heap size in bytes
+func peakHeap(f func()) uint64 {
+	runtime.GC()
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	base := stats.HeapAlloc
+
+	var peak uint64
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(time.Millisecond)
+		defer ticker.Stop()
+		for {
+			var s runtime.MemStats
+			runtime.ReadMemStats(&s)
+			if s.HeapAlloc > base && s.HeapAlloc-base > peak {
+				peak = s.HeapAlloc - base
+			}
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	f()
+	close(done)
+	wg.Wait()
+
+	return peak
+}
+
+// BenchmarkReadJSON decodes skipped checks from a large report (about 200 MB) as a stream,
+// the peak heap is bounded by the size of the largest entry and the rendered findings.
+func BenchmarkReadJSON(b *testing.B) {
+	b.ReportAllocs()
+	var peak uint64
+	for i := 0; i < b.N; i++ {
+		peak = peakHeap(func() {
+			results, _, err := ReadJSON(newSyntheticReport(60000, 100), ReadOptions{ResultTypes: []string{config.ResultTypeSkipped}})
+			if err != nil {
+				b.Fatal(err)
+			}
+			if len(results.Results.SkippedChecks) != 100 {
+				b.Fatalf("unexpected number of skipped checks: %d", len(results.Results.SkippedChecks))
+			}
+		})
+	}
+	b.ReportMetric(float64(peak)/(1<<20), "peak-heap-MB")
+}
+
+// BenchmarkReadJSON_ReadAll reads the same report in full before parsing it, for comparison
+func BenchmarkReadJSON_ReadAll(b *testing.B) {
+	b.ReportAllocs()
+	var peak uint64
+	for i := 0; i < b.N; i++ {
+		peak = peakHeap(func() {
+			data, err := io.ReadAll(newSyntheticReport(60000, 100))
+			if err != nil {
+				b.Fatal(err)
+			}
+			if _, _, err = ReadJSON(bytes.NewReader(data), ReadOptions{CodeBlocks: true}); err != nil {
+				b.Fatal(err)
+			}
+		})
+	}
+	b.ReportMetric(float64(peak)/(1<<20), "peak-heap-MB")
+}
@@ -0,0 +1,224 @@
+/*
+Copyright © 2023 The checkov-docs Authors
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package parser
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/checkov-docs/checkov-docs/internal/models"
+)
+
+// junitTestCaseName matches the name of a checkov test case, e.g. `[HIGH][CKV_AWS_115] Ensure ...`
+var junitTestCaseName = regexp.MustCompile(`^(?:\[([^\]]*)\])?\[([^\]]+)\]\s*(.*)$`)
+
+// junitTestSuites is a struct unmarshalled from a JUnit XML-formatted checkov output
+type junitTestSuites struct {
+	XMLName    xml.Name          `xml:"testsuites"`
+	TestSuites []*junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string           `xml:"name,attr"`
+	TestCases []*junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	File      string        `xml:"file,attr"`
+	Skipped   *junitMessage `xml:"skipped"`
+	Failure   *junitMessage `xml:"failure"`
+	Error     *junitMessage `xml:"error"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// ParseJUnitXML returns checkov results unmarshalled from JUnit XML-formatted `data`.
+//
+// Test cases with a `<skipped>` element are mapped to skipped checks using its message as
+// suppress comment, test cases with a `<failure>` or `<error>` element to failed checks and
+// all other test cases to passed checks. Each test suite is a report, its check type
+// is taken from the test suite name, e.g. `terraform scan`. The summary is computed
+// from the test cases because JUnit XML doesn't include the checkov version.
+func ParseJUnitXML(data []byte) (*models.CheckovResults, []Warning, error) {
+	suites, err := unmarshalJUnitXML(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var warnings []Warning
+	reports := make([]*models.CheckovResults, 0, len(suites))
+	for i, suite := range suites {
+		report, suiteWarnings := parseJUnitTestSuite(i, suite)
+		warnings = append(warnings, suiteWarnings...)
+		reports = append(reports, report)
+	}
+
+	return Merge(reports...), warnings, nil
+}
+
+// unmarshalJUnitXML returns the test suites in `data`, the root element is either
+// `<testsuites>` or a single `<testsuite>`
+func unmarshalJUnitXML(data []byte) ([]*junitTestSuite, error) {
+	if bytes.Contains(data, []byte("<testsuites")) {
+		suites := &junitTestSuites{}
+		if err := xml.Unmarshal(data, suites); err != nil {
+			return nil, err
+		}
+		return suites.TestSuites, nil
+	}
+
+	if bytes.Contains(data, []byte("<testsuite")) {
+		suite := &junitTestSuite{}
+		if err := xml.Unmarshal(data, suite); err != nil {
+			return nil, err
+		}
+		return []*junitTestSuite{suite}, nil
+	}
+
+	return nil, errors.New("checkov results have no testsuite element")
+}
+
+// parseJUnitTestSuite returns the findings of a single test suite
+func parseJUnitTestSuite(index int, suite *junitTestSuite) (*models.CheckovResults, []Warning) {
+	var warnings []Warning
+	report := &models.CheckovResults{
+		CheckType: strings.TrimSuffix(strings.TrimSpace(suite.Name), " scan"),
+		Results:   &models.Results{},
+		Summary:   &models.Summary{},
+	}
+
+	for i, testCase := range suite.TestCases {
+		check := newJUnitCheck(testCase)
+		if check.CheckID == "" {
+			warnings = append(warnings, Warning{Report: index, Section: "testcase", Index: i, Message: "test case has no check ID"})
+			continue
+		}
+
+		switch check.CheckResult.Result {
+		case "SKIPPED":
+			report.Results.SkippedChecks = append(report.Results.SkippedChecks, check)
+			report.Summary.Skipped++
+		case "FAILED":
+			report.Results.FailedChecks = append(report.Results.FailedChecks, check)
+			report.Summary.Failed++
+		default:
+			report.Results.PassedChecks = append(report.Results.PassedChecks, check)
+			report.Summary.Passed++
+		}
+	}
+
+	return report, warnings
+}
+
+// newJUnitCheck returns a check created from a JUnit XML test case
+func newJUnitCheck(testCase *junitTestCase) *models.Check {
+	check := &models.Check{
+		FilePath:    testCase.File,
+		CheckResult: &models.CheckResult{Result: "PASSED"},
+	}
+
+	if match := junitTestCaseName.FindStringSubmatch(strings.TrimSpace(testCase.Name)); match != nil {
+		if match[1] != "NONE" {
+			check.Severity = match[1]
+		}
+		check.CheckID = match[2]
+		check.CheckName = match[3]
+	}
+
+	// the class name is the file path followed by the resource, e.g. `/main.tf.aws_s3_bucket.example`
+	if check.FilePath != "" && strings.HasPrefix(testCase.ClassName, check.FilePath+".") {
+		check.Resource = strings.TrimPrefix(testCase.ClassName, check.FilePath+".")
+	}
+
+	switch {
+	case testCase.Skipped != nil:
+		check.CheckResult.Result = "SKIPPED"
+		check.CheckResult.SuppressComment = testCase.Skipped.Message
+		if check.CheckResult.SuppressComment == "" {
+			check.CheckResult.SuppressComment = strings.TrimSpace(testCase.Skipped.Text)
+		}
+	case testCase.Failure != nil:
+		check.CheckResult.Result = "FAILED"
+		parseJUnitDetails(check, testCase.Failure.Text)
+	case testCase.Error != nil:
+		check.CheckResult.Result = "FAILED"
+		parseJUnitDetails(check, testCase.Error.Text)
+	}
+
+	return check
+}
+
+// parseJUnitDetails sets the resource, file and guideline of `check` from the
+// `Key: value` lines that checkov writes in the body of a failure
+func parseJUnitDetails(check *models.Check, text string) {
+	for _, line := range strings.Split(text, "\n") {
+		key, value, found := strings.Cut(strings.TrimSpace(line), ":")
+		if !found {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch key {
+		case "Resource":
+			if check.Resource == "" {
+				check.Resource = value
+			}
+		case "File":
+			// the file path is followed by the line range, e.g. `/main.tf: 1-12`
+			path, lines, _ := strings.Cut(value, ":")
+			if check.FilePath == "" {
+				check.FilePath = strings.TrimSpace(path)
+			}
+			check.FileLineRange = parseLineRange(lines)
+		case "Guideline":
+			check.Guideline = value
+		}
+	}
+}
+
+// parseLineRange returns the line range in `lines`, e.g. `1-12`, or nil if it's malformed
+func parseLineRange(lines string) []int {
+	start, end, found := strings.Cut(strings.TrimSpace(lines), "-")
+	if !found {
+		return nil
+	}
+
+	startLine, err := strconv.Atoi(start)
+	if err != nil {
+		return nil
+	}
+	endLine, err := strconv.Atoi(end)
+	if err != nil {
+		return nil
+	}
+
+	return []int{startLine, endLine}
+}
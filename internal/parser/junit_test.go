@@ -0,0 +1,117 @@
+/*
+Copyright © 2023 The checkov-docs Authors
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package parser
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/checkov-docs/checkov-docs/internal/config"
+	"github.com/checkov-docs/checkov-docs/internal/models"
+)
+
+func TestParseJUnitXML(t *testing.T) {
+	assert := assert.New(t)
+
+	// Prepare test data
+	data, err := os.ReadFile("testdata/results.xml")
+	assert.Nil(err, "unexpected error reading test data", err)
+
+	// Test parsing JUnit XML results
+	results, warnings, err := ParseJUnitXML(data)
+	assert.Nil(err, "unexpected error while parsing JUnit XML results", err)
+
+	// Assert that skipped test cases are mapped to skipped checks
+	assert.Equal([]*models.Check{
+		{
+			FilePath:    "/main.tf",
+			CheckID:     "CKV_AWS_115",
+			CheckName:   "Ensure that AWS Lambda function is configured for function-level concurrent execution limit",
+			Resource:    "aws_lambda_function.example",
+			CheckResult: &models.CheckResult{Result: "SKIPPED", SuppressComment: "hello world"},
+			CheckType:   "terraform",
+		},
+		{
+			FilePath:    "/deployment.yaml",
+			CheckID:     "CKV_K8S_21",
+			CheckName:   "The default namespace should not be used",
+			Resource:    "Deployment.default.app",
+			Severity:    "HIGH",
+			CheckResult: &models.CheckResult{Result: "SKIPPED", SuppressComment: "default namespace is used in dev"},
+			CheckType:   "kubernetes",
+		},
+	}, results.Results.SkippedChecks)
+
+	// Assert that failed test cases are mapped to failed checks
+	assert.Len(results.Results.FailedChecks, 1)
+	assert.Equal("CKV_AWS_116", results.Results.FailedChecks[0].CheckID)
+	assert.Equal("https://docs.bridgecrew.io/docs/ensure-that-aws-lambda-function-is-configured-for-a-dead-letter-queue-dlq", results.Results.FailedChecks[0].Guideline)
+	assert.Equal([]int{1, 12}, results.Results.FailedChecks[0].FileLineRange)
+
+	// Assert that other test cases are mapped to passed checks
+	assert.Len(results.Results.PassedChecks, 1)
+	assert.Equal("CKV_AWS_45", results.Results.PassedChecks[0].CheckID)
+	assert.Equal(&models.Summary{Passed: 1, Failed: 1, Skipped: 2}, results.Summary)
+
+	// Assert that test cases without check ID are reported
+	assert.Len(warnings, 1)
+	assert.Equal("report[1].testcase[1]: test case has no check ID", warnings[0].String())
+}
+
+func TestParseJUnitXML_SingleTestSuite(t *testing.T) {
+	assert := assert.New(t)
+
+	// Prepare test data
+	data := []byte(`<testsuite name="dockerfile scan"><testcase name="[NONE][CKV_DOCKER_2] Ensure that HEALTHCHECK instructions have been added to container images" classname="/Dockerfile./Dockerfile." file="/Dockerfile"><skipped type="skipped" message="healthcheck is defined by the orchestrator"/></testcase></testsuite>`)
+
+	// Test parsing a single test suite
+	results, warnings, err := ParseJUnitXML(data)
+	assert.Nil(err, "unexpected error while parsing JUnit XML results", err)
+	assert.Empty(warnings)
+	assert.Equal("dockerfile", results.CheckType)
+	assert.Len(results.Results.SkippedChecks, 1)
+	assert.Equal("/Dockerfile.", results.Results.SkippedChecks[0].Resource)
+}
+
+func TestParseJUnitXML_Error(t *testing.T) {
+	assert := assert.New(t)
+
+	// Test parsing malformed JUnit XML results
+	_, _, err := ParseJUnitXML([]byte(`<testsuites><testsuite>`))
+	assert.NotNil(err, "expected an error while parsing malformed JUnit XML results, but got no error")
+	_, _, err = ParseJUnitXML([]byte(`<results/>`))
+	assert.NotNil(err, "expected an error while parsing XML without test suites, but got no error")
+}
+
+func TestDetectFormat_JUnitXML(t *testing.T) {
+	assert := assert.New(t)
+
+	// Prepare test data
+	data, err := os.ReadFile("testdata/results.xml")
+	assert.Nil(err, "unexpected error reading test data", err)
+
+	// Test detecting JUnit XML results
+	assert.Equal(config.InputFormatJUnitXML, DetectFormat(data))
+}
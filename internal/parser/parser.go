@@ -0,0 +1,239 @@
+/*
+Copyright © 2023 The checkov-docs Authors
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/checkov-docs/checkov-docs/internal/config"
+	"github.com/checkov-docs/checkov-docs/internal/models"
+)
+
+// Warning describes a malformed entry found while parsing checkov results.
+// Malformed entries are reported instead of failing the whole parse.
+type Warning struct {
+	// Report is the index of the report in an array output, 0 for a single object
+	Report int
+	// Section is the path of the section holding the entry, e.g. results.skipped_checks
+	Section string
+	// Index is the index of the entry in the section, -1 if the whole section is malformed
+	Index int
+	// Message describes why the entry is malformed
+	Message string
+}
+
+// String returns a human-readable representation of the warning
+func (w Warning) String() string {
+	if w.Section == "" {
+		return fmt.Sprintf("report[%d]: %s", w.Report, w.Message)
+	}
+	if w.Index < 0 {
+		return fmt.Sprintf("report[%d].%s: %s", w.Report, w.Section, w.Message)
+	}
+	return fmt.Sprintf("report[%d].%s[%d]: %s", w.Report, w.Section, w.Index, w.Message)
+}
+
+// detectSize is the number of bytes read from the beginning of the input to detect its format
+const detectSize = 4096
+
+// ReadOptions stores the settings used to read checkov results
+type ReadOptions struct {
+	// ResultTypes are the result types to decode, all result types are decoded if empty
+	ResultTypes []string
+	// CodeBlocks keeps the code block of each check, code blocks are dropped otherwise
+	// because they make up most of the size of checkov results
+	CodeBlocks bool
+}
+
+// Read returns checkov results read from `r` in `format`.
+// If `format` is empty or `auto`, the format is detected from the beginning of the content.
+//
+// JSON-formatted results are decoded as a stream and only the checks and fields selected
+// in `opts` are decoded, see ReadJSON. Other formats are read in full before being parsed.
+func Read(r io.Reader, format string, opts ReadOptions) (*models.CheckovResults, []Warning, error) {
+	br := bufio.NewReaderSize(r, detectSize)
+	if format == "" || format == config.InputFormatAuto {
+		// a short or empty input is not an error here, the parser reports it
+		head, _ := br.Peek(detectSize)
+		format = DetectFormat(head)
+	}
+
+	switch format {
+	case config.InputFormatJSON:
+		return ReadJSON(br, opts)
+	case config.InputFormatSARIF, config.InputFormatJUnitXML:
+		data, err := io.ReadAll(br)
+		if err != nil {
+			return nil, nil, err
+		}
+		if format == config.InputFormatSARIF {
+			return ParseSARIF(data)
+		}
+		return ParseJUnitXML(data)
+	default:
+		return nil, nil, fmt.Errorf("invalid input format %q, valid values: %v", format, config.InputFormats)
+	}
+}
+
+// DetectFormat returns the input format of `head`, the beginning of the content, defaults to JSON
+func DetectFormat(head []byte) string {
+	trimmed := bytes.TrimSpace(head)
+	switch {
+	case len(trimmed) > 0 && trimmed[0] == '<':
+		return config.InputFormatJUnitXML
+	case len(trimmed) > 0 && trimmed[0] == '{' && isSARIF(trimmed):
+		return config.InputFormatSARIF
+	default:
+		return config.InputFormatJSON
+	}
+}
+
+// Merge returns a single result containing the findings of all `reports`.
+// The check type of the merged result is only set when all reports share the same one.
+func Merge(reports ...*models.CheckovResults) *models.CheckovResults {
+	merged := &models.CheckovResults{Results: &models.Results{}}
+
+	for i, report := range reports {
+		if report == nil {
+			continue
+		}
+		if i == 0 {
+			merged.CheckType = report.CheckType
+		} else if merged.CheckType != report.CheckType {
+			merged.CheckType = ""
+		}
+		merged.Summary = mergeSummary(merged.Summary, report.Summary)
+		if report.Results == nil {
+			continue
+		}
+		merged.Results.PassedChecks = appendChecks(merged.Results.PassedChecks, report.Results.PassedChecks, report.CheckType)
+		merged.Results.FailedChecks = appendChecks(merged.Results.FailedChecks, report.Results.FailedChecks, report.CheckType)
+		merged.Results.SkippedChecks = appendChecks(merged.Results.SkippedChecks, report.Results.SkippedChecks, report.CheckType)
+	}
+
+	return merged
+}
+
+// Deduplicate removes checks with the same file, see dedupePath, check ID and resource as a previous check
+// of the same result type, e.g. when reports of overlapping directories are merged.
+// It returns the number of removed checks.
+func Deduplicate(results *models.CheckovResults) int {
+	if results == nil || results.Results == nil {
+		return 0
+	}
+
+	var removed, n int
+	results.Results.PassedChecks, n = deduplicateChecks(results.Results.PassedChecks)
+	removed += n
+	results.Results.FailedChecks, n = deduplicateChecks(results.Results.FailedChecks)
+	removed += n
+	results.Results.SkippedChecks, n = deduplicateChecks(results.Results.SkippedChecks)
+	removed += n
+
+	return removed
+}
+
+// deduplicateChecks returns `checks` without duplicates, the first occurrence is kept
+func deduplicateChecks(checks []*models.Check) ([]*models.Check, int) {
+	type key struct {
+		filePath, checkID, resource string
+	}
+
+	seen := make(map[key]bool, len(checks))
+	unique := checks[:0]
+	for _, check := range checks {
+		k := key{dedupePath(check), check.CheckID, check.Resource}
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+		unique = append(unique, check)
+	}
+
+	return unique, len(checks) - len(unique)
+}
+
+// dedupePath returns the path identifying the file of `check` across reports. The file path is relative
+// to the scanned directory, so the absolute path, then the path in the repository, are used if available.
+func dedupePath(check *models.Check) string {
+	switch {
+	case check.FileAbsPath != "":
+		return check.FileAbsPath
+	case check.RepoFilePath != "":
+		return check.RepoFilePath
+	default:
+		return check.FilePath
+	}
+}
+
+// SetSource sets the source of all checks in `results` to `name`
+func SetSource(results *models.CheckovResults, name string) {
+	if results == nil || results.Results == nil {
+		return
+	}
+
+	for _, checks := range [][]*models.Check{results.Results.PassedChecks, results.Results.FailedChecks, results.Results.SkippedChecks} {
+		for _, check := range checks {
+			check.Source = name
+		}
+	}
+}
+
+// mergeSummary returns the sum of `dst` and `summary`.
+// The checkov version is taken from the first summary that has one.
+func mergeSummary(dst, summary *models.Summary) *models.Summary {
+	if summary == nil {
+		return dst
+	}
+	if dst == nil {
+		dst = &models.Summary{}
+	}
+
+	dst.Passed += summary.Passed
+	dst.Failed += summary.Failed
+	dst.Skipped += summary.Skipped
+	dst.ParsingErrors += summary.ParsingErrors
+	dst.ResourceCount += summary.ResourceCount
+	if dst.CheckovVersion == "" {
+		dst.CheckovVersion = summary.CheckovVersion
+	}
+
+	return dst
+}
+
+// appendChecks appends `checks` to `dst` and sets their check type if not already set
+func appendChecks(dst, checks []*models.Check, checkType string) []*models.Check {
+	for _, check := range checks {
+		if check == nil {
+			continue
+		}
+		if check.CheckType == "" {
+			check.CheckType = checkType
+		}
+		dst = append(dst, check)
+	}
+	return dst
+}
@@ -0,0 +1,245 @@
+/*
+Copyright © 2023 The checkov-docs Authors
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package parser
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/checkov-docs/checkov-docs/internal/models"
+)
+
+func TestReadJSON_Object(t *testing.T) {
+	assert := assert.New(t)
+
+	// Prepare test data
+	data := []byte(`{"check_type": "terraform", "results": {"skipped_checks": [{"check_id": "CKV_AWS_115", "check_result": {"result": "SKIPPED", "suppress_comment": "hello world"}}]}}`)
+
+	// Test parsing a single result object
+	results, warnings, err := ReadJSON(bytes.NewReader(data), ReadOptions{CodeBlocks: true})
+	assert.Nil(err, "unexpected error while parsing result object", err)
+	assert.Empty(warnings)
+	assert.Equal("terraform", results.CheckType)
+	assert.Len(results.Results.SkippedChecks, 1)
+	assert.Equal("terraform", results.Results.SkippedChecks[0].CheckType)
+}
+
+func TestReadJSON_Array(t *testing.T) {
+	assert := assert.New(t)
+
+	// Prepare test data
+	data, err := os.ReadFile("testdata/multi-framework.json")
+	assert.Nil(err, "unexpected error reading test data", err)
+
+	// Test parsing an array of result objects
+	results, warnings, err := ReadJSON(bytes.NewReader(data), ReadOptions{CodeBlocks: true})
+	assert.Nil(err, "unexpected error while parsing result array", err)
+	assert.Empty(warnings)
+	assert.Equal("", results.CheckType)
+	assert.Len(results.Results.FailedChecks, 1)
+	assert.Len(results.Results.SkippedChecks, 3)
+
+	checkTypes := make([]string, 0, len(results.Results.SkippedChecks))
+	for _, check := range results.Results.SkippedChecks {
+		checkTypes = append(checkTypes, check.CheckType)
+	}
+	assert.Equal([]string{"terraform", "kubernetes", "dockerfile"}, checkTypes)
+}
+
+func TestReadJSON_Incomplete(t *testing.T) {
+	assert := assert.New(t)
+
+	// Test parsing empty and malformed data
+	_, _, err := ReadJSON(strings.NewReader("  "), ReadOptions{CodeBlocks: true})
+	assert.NotNil(err, "expected an error while parsing empty data, but got no error")
+	_, _, err = ReadJSON(strings.NewReader(`[{"check_type": "terraform"`), ReadOptions{CodeBlocks: true})
+	assert.NotNil(err, "expected an error while parsing malformed data, but got no error")
+}
+
+func TestReadJSON_SummaryOnly(t *testing.T) {
+	assert := assert.New(t)
+
+	// Prepare test data
+	data, err := os.ReadFile("testdata/summary-only.json")
+	assert.Nil(err, "unexpected error reading test data", err)
+
+	// Test parsing a report without results
+	results, warnings, err := ReadJSON(bytes.NewReader(data), ReadOptions{CodeBlocks: true})
+	assert.Nil(err, "unexpected error while parsing summary-only report", err)
+	assert.Empty(warnings)
+	assert.Empty(results.Results.SkippedChecks)
+	assert.Equal(&models.Summary{ResourceCount: 0, CheckovVersion: "2.3.296"}, results.Summary)
+}
+
+func TestReadJSON_BareSummary(t *testing.T) {
+	assert := assert.New(t)
+
+	// Prepare test data
+	data := []byte(`{"passed": 0, "failed": 0, "skipped": 0, "parsing_errors": 1, "resource_count": 0, "checkov_version": "2.3.296"}`)
+
+	// Test parsing a summary emitted at the top level
+	results, warnings, err := ReadJSON(bytes.NewReader(data), ReadOptions{CodeBlocks: true})
+	assert.Nil(err, "unexpected error while parsing bare summary", err)
+	assert.Empty(warnings)
+	assert.NotNil(results.Results)
+	assert.Equal(&models.Summary{ParsingErrors: 1, CheckovVersion: "2.3.296"}, results.Summary)
+}
+
+func TestReadJSON_Malformed(t *testing.T) {
+	assert := assert.New(t)
+
+	// Prepare test data
+	data, err := os.ReadFile("testdata/malformed.json")
+	assert.Nil(err, "unexpected error reading test data", err)
+
+	// Test parsing a report with malformed entries
+	results, warnings, err := ReadJSON(bytes.NewReader(data), ReadOptions{CodeBlocks: true})
+	assert.Nil(err, "unexpected error while parsing malformed entries", err)
+
+	// Assert that valid entries are kept, including the skipped check without check_result
+	assert.Len(results.Results.SkippedChecks, 2)
+	assert.Equal("CKV_AWS_115", results.Results.SkippedChecks[0].CheckID)
+	assert.Equal("CKV_AWS_116", results.Results.SkippedChecks[1].CheckID)
+	assert.NotNil(results.Results.SkippedChecks[1].CheckResult)
+	assert.Empty(results.Results.FailedChecks)
+
+	// Assert that malformed entries are reported with their index
+	expected := []string{
+		"report[0].results.failed_checks: section is not an array",
+		"report[0].results.skipped_checks[1]: entry is null",
+		"report[0].results.skipped_checks[2]: check_id: json: cannot unmarshal number into Go value of type string",
+		"report[0].results.skipped_checks[3]: entry has no check_result",
+		"report[1]: report is not an object",
+	}
+	actual := make([]string, len(warnings))
+	for i, warning := range warnings {
+		actual[i] = warning.String()
+	}
+	assert.Equal(expected, actual)
+}
+
+func TestMerge(t *testing.T) {
+	assert := assert.New(t)
+
+	// Prepare test data
+	reports := []*models.CheckovResults{
+		{CheckType: "terraform", Results: &models.Results{SkippedChecks: []*models.Check{{CheckID: "CKV_AWS_115"}}}},
+		{CheckType: "terraform", Results: nil},
+		{CheckType: "terraform", Results: &models.Results{SkippedChecks: []*models.Check{{CheckID: "CKV_AWS_116", CheckType: "custom"}}}},
+	}
+
+	// Test merging reports with the same check type
+	merged := Merge(reports...)
+	assert.Equal("terraform", merged.CheckType)
+	assert.Len(merged.Results.SkippedChecks, 2)
+	assert.Equal("terraform", merged.Results.SkippedChecks[0].CheckType)
+	assert.Equal("custom", merged.Results.SkippedChecks[1].CheckType)
+	assert.Nil(merged.Summary)
+}
+
+func TestMerge_Summary(t *testing.T) {
+	assert := assert.New(t)
+
+	// Prepare test data
+	reports := []*models.CheckovResults{
+		{CheckType: "terraform", Summary: &models.Summary{Passed: 3, Failed: 1, Skipped: 2, ResourceCount: 4}},
+		{CheckType: "kubernetes", Summary: &models.Summary{Passed: 1, ParsingErrors: 1, ResourceCount: 1, CheckovVersion: "2.3.296"}},
+	}
+
+	// Test merging summaries of reports with different check types
+	merged := Merge(reports...)
+	assert.Equal("", merged.CheckType)
+	assert.Equal(&models.Summary{Passed: 4, Failed: 1, Skipped: 2, ParsingErrors: 1, ResourceCount: 5, CheckovVersion: "2.3.296"}, merged.Summary)
+}
+
+func TestDeduplicate(t *testing.T) {
+	assert := assert.New(t)
+
+	// Prepare test data
+	results := &models.CheckovResults{Results: &models.Results{
+		FailedChecks: []*models.Check{
+			{FilePath: "/main.tf", CheckID: "CKV_AWS_116", Resource: "aws_lambda_function.example", Source: "a.json"},
+		},
+		SkippedChecks: []*models.Check{
+			{FilePath: "/main.tf", CheckID: "CKV_AWS_116", Resource: "aws_lambda_function.example", Source: "a.json"},
+			{FilePath: "/main.tf", CheckID: "CKV_AWS_115", Resource: "aws_lambda_function.example", Source: "a.json"},
+			{FilePath: "/main.tf", CheckID: "CKV_AWS_116", Resource: "aws_lambda_function.example", Source: "b.json"},
+			{FilePath: "/lambda.tf", CheckID: "CKV_AWS_116", Resource: "aws_lambda_function.example", Source: "b.json"},
+		},
+	}}
+
+	// Test removing duplicate checks, checks of different result types are not duplicates
+	removed := Deduplicate(results)
+	assert.Equal(1, removed)
+	assert.Len(results.Results.FailedChecks, 1)
+	assert.Len(results.Results.SkippedChecks, 3)
+	assert.Equal("a.json", results.Results.SkippedChecks[0].Source)
+	assert.Equal("/lambda.tf", results.Results.SkippedChecks[2].FilePath)
+	assert.Equal(0, Deduplicate(&models.CheckovResults{}))
+}
+
+func TestDeduplicate_AbsolutePaths(t *testing.T) {
+	assert := assert.New(t)
+
+	// Prepare test data, reports of two directories of a monorepo with the same relative path
+	results := Merge(
+		&models.CheckovResults{Results: &models.Results{SkippedChecks: []*models.Check{
+			{FilePath: "/main.tf", FileAbsPath: "/repo/modules/a/main.tf", CheckID: "CKV_AWS_18", Resource: "aws_s3_bucket.this", Source: "a.json"},
+			{FilePath: "/variables.tf", RepoFilePath: "/modules/a/variables.tf", CheckID: "CKV_AWS_18", Resource: "aws_s3_bucket.that", Source: "a.json"},
+		}}},
+		&models.CheckovResults{Results: &models.Results{SkippedChecks: []*models.Check{
+			{FilePath: "/main.tf", FileAbsPath: "/repo/modules/b/main.tf", CheckID: "CKV_AWS_18", Resource: "aws_s3_bucket.this", Source: "b.json"},
+			{FilePath: "/variables.tf", RepoFilePath: "/modules/b/variables.tf", CheckID: "CKV_AWS_18", Resource: "aws_s3_bucket.that", Source: "b.json"},
+			{FilePath: "/main.tf", FileAbsPath: "/repo/modules/a/main.tf", CheckID: "CKV_AWS_18", Resource: "aws_s3_bucket.this", Source: "b.json"},
+		}}},
+	)
+
+	// Test findings of different directories are kept, only the finding with the same absolute path is removed
+	removed := Deduplicate(results)
+	assert.Equal(1, removed)
+	assert.Len(results.Results.SkippedChecks, 4)
+	for i, source := range []string{"a.json", "a.json", "b.json", "b.json"} {
+		assert.Equal(source, results.Results.SkippedChecks[i].Source, "unexpected source of check %d", i)
+	}
+}
+
+func TestSetSource(t *testing.T) {
+	assert := assert.New(t)
+
+	// Prepare test data
+	results := &models.CheckovResults{Results: &models.Results{
+		PassedChecks:  []*models.Check{{CheckID: "CKV_AWS_45"}},
+		FailedChecks:  []*models.Check{{CheckID: "CKV_AWS_116"}},
+		SkippedChecks: []*models.Check{{CheckID: "CKV_AWS_115"}},
+	}}
+
+	// Test setting the source of all checks
+	SetSource(results, "reports/lambda.json")
+	assert.Equal("reports/lambda.json", results.Results.PassedChecks[0].Source)
+	assert.Equal("reports/lambda.json", results.Results.FailedChecks[0].Source)
+	assert.Equal("reports/lambda.json", results.Results.SkippedChecks[0].Source)
+}
@@ -0,0 +1,257 @@
+/*
+Copyright © 2023 The checkov-docs Authors
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package parser
+
+import (
+	"encoding/json"
+	"regexp"
+
+	"github.com/checkov-docs/checkov-docs/internal/models"
+)
+
+// sarifLog is a struct unmarshalled from a SARIF-formatted checkov output.
+// Only the properties used to document findings are unmarshalled.
+type sarifLog struct {
+	Version string      `json:"version"`
+	Runs    []*sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool          `json:"tool"`
+	Results []*json.RawMessage `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name    string       `json:"name"`
+	Version string       `json:"version"`
+	Rules   []*sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	Name             string       `json:"name"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+	HelpURI          string       `json:"helpUri"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID       string              `json:"ruleId"`
+	RuleIndex    *int                `json:"ruleIndex"`
+	Kind         string              `json:"kind"`
+	Message      sarifMessage        `json:"message"`
+	Locations    []*sarifLocation    `json:"locations"`
+	Suppressions []*sarifSuppression `json:"suppressions"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation *struct {
+		ArtifactLocation struct {
+			URI string `json:"uri"`
+		} `json:"artifactLocation"`
+		Region *struct {
+			StartLine int `json:"startLine"`
+			EndLine   int `json:"endLine"`
+		} `json:"region"`
+	} `json:"physicalLocation"`
+	LogicalLocations []*struct {
+		Name               string `json:"name"`
+		FullyQualifiedName string `json:"fullyQualifiedName"`
+	} `json:"logicalLocations"`
+}
+
+type sarifSuppression struct {
+	Kind          string `json:"kind"`
+	Status        string `json:"status"`
+	Justification string `json:"justification"`
+}
+
+// ParseSARIF returns checkov results unmarshalled from SARIF-formatted `data`.
+//
+// Results with an accepted suppression are mapped to skipped checks using the justification
+// as suppress comment, results of kind `pass` to passed checks and all other results to failed checks.
+// The summary is computed from the results because SARIF doesn't include one.
+func ParseSARIF(data []byte) (*models.CheckovResults, []Warning, error) {
+	log := &sarifLog{}
+	if err := json.Unmarshal(data, log); err != nil {
+		return nil, nil, err
+	}
+
+	var warnings []Warning
+	reports := make([]*models.CheckovResults, 0, len(log.Runs))
+	for i, run := range log.Runs {
+		if run == nil {
+			warnings = append(warnings, Warning{Report: i, Section: "", Index: -1, Message: "run is null"})
+			continue
+		}
+		report, runWarnings := parseSARIFRun(i, run)
+		warnings = append(warnings, runWarnings...)
+		reports = append(reports, report)
+	}
+
+	return Merge(reports...), warnings, nil
+}
+
+// parseSARIFRun returns the findings of a single SARIF run
+func parseSARIFRun(index int, run *sarifRun) (*models.CheckovResults, []Warning) {
+	var warnings []Warning
+	report := &models.CheckovResults{
+		Results: &models.Results{},
+		Summary: &models.Summary{CheckovVersion: run.Tool.Driver.Version},
+	}
+
+	rules := make(map[string]*sarifRule, len(run.Tool.Driver.Rules))
+	for _, rule := range run.Tool.Driver.Rules {
+		if rule != nil {
+			rules[rule.ID] = rule
+		}
+	}
+
+	for i, raw := range run.Results {
+		if raw == nil {
+			warnings = append(warnings, Warning{Report: index, Section: "results", Index: i, Message: "result is null"})
+			continue
+		}
+
+		result := &sarifResult{}
+		if err := json.Unmarshal(*raw, result); err != nil {
+			warnings = append(warnings, Warning{Report: index, Section: "results", Index: i, Message: err.Error()})
+			continue
+		}
+
+		rule := getSARIFRule(run.Tool.Driver.Rules, rules, result)
+		if result.RuleID == "" && rule == nil {
+			warnings = append(warnings, Warning{Report: index, Section: "results", Index: i, Message: "result has no ruleId"})
+			continue
+		}
+
+		check := newSARIFCheck(result, rule)
+		switch {
+		case check.CheckResult.Result == "SKIPPED":
+			report.Results.SkippedChecks = append(report.Results.SkippedChecks, check)
+			report.Summary.Skipped++
+		case check.CheckResult.Result == "PASSED":
+			report.Results.PassedChecks = append(report.Results.PassedChecks, check)
+			report.Summary.Passed++
+		default:
+			report.Results.FailedChecks = append(report.Results.FailedChecks, check)
+			report.Summary.Failed++
+		}
+	}
+
+	return report, warnings
+}
+
+// getSARIFRule returns the rule referenced by `result`, either by its index or its ID
+func getSARIFRule(ruleList []*sarifRule, rules map[string]*sarifRule, result *sarifResult) *sarifRule {
+	if result.RuleIndex != nil && *result.RuleIndex >= 0 && *result.RuleIndex < len(ruleList) {
+		rule := ruleList[*result.RuleIndex]
+		if rule != nil && (result.RuleID == "" || rule.ID == result.RuleID) {
+			return rule
+		}
+	}
+	return rules[result.RuleID]
+}
+
+// newSARIFCheck returns a check created from a SARIF result and the rule it references
+func newSARIFCheck(result *sarifResult, rule *sarifRule) *models.Check {
+	check := &models.Check{
+		CheckID:     result.RuleID,
+		CheckName:   result.Message.Text,
+		CheckResult: &models.CheckResult{Result: "FAILED"},
+	}
+
+	if rule != nil {
+		if check.CheckID == "" {
+			check.CheckID = rule.ID
+		}
+		if rule.ShortDescription.Text != "" {
+			check.CheckName = rule.ShortDescription.Text
+		} else if check.CheckName == "" {
+			check.CheckName = rule.Name
+		}
+		check.Guideline = rule.HelpURI
+	}
+
+	if len(result.Locations) > 0 && result.Locations[0] != nil {
+		location := result.Locations[0]
+		if location.PhysicalLocation != nil {
+			check.FilePath = location.PhysicalLocation.ArtifactLocation.URI
+			if region := location.PhysicalLocation.Region; region != nil && region.StartLine > 0 {
+				endLine := region.EndLine
+				if endLine < region.StartLine {
+					endLine = region.StartLine
+				}
+				check.FileLineRange = []int{region.StartLine, endLine}
+			}
+		}
+		if len(location.LogicalLocations) > 0 && location.LogicalLocations[0] != nil {
+			check.Resource = location.LogicalLocations[0].FullyQualifiedName
+			if check.Resource == "" {
+				check.Resource = location.LogicalLocations[0].Name
+			}
+		}
+	}
+
+	if suppression := getSARIFSuppression(result.Suppressions); suppression != nil {
+		check.CheckResult.Result = "SKIPPED"
+		check.CheckResult.SuppressComment = suppression.Justification
+	} else if result.Kind == "pass" {
+		check.CheckResult.Result = "PASSED"
+	}
+
+	return check
+}
+
+// getSARIFSuppression returns the first suppression that isn't rejected or under review
+func getSARIFSuppression(suppressions []*sarifSuppression) *sarifSuppression {
+	for _, suppression := range suppressions {
+		if suppression != nil && (suppression.Status == "" || suppression.Status == "accepted") {
+			return suppression
+		}
+	}
+	return nil
+}
+
+// sarifMarkers match the properties of a SARIF log, either the `runs` key or a SARIF `$schema`
+var sarifMarkers = []*regexp.Regexp{
+	regexp.MustCompile(`"runs"\s*:`),
+	regexp.MustCompile(`"\$schema"\s*:\s*"[^"]*(?i:sarif)`),
+}
+
+// isSARIF returns true if `head`, the beginning of a JSON document, looks like a SARIF log
+func isSARIF(head []byte) bool {
+	for _, marker := range sarifMarkers {
+		if marker.Match(head) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,106 @@
+/*
+Copyright © 2023 The checkov-docs Authors
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package parser
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/checkov-docs/checkov-docs/internal/config"
+	"github.com/checkov-docs/checkov-docs/internal/models"
+)
+
+func TestParseSARIF(t *testing.T) {
+	assert := assert.New(t)
+
+	// Prepare test data
+	data, err := os.ReadFile("testdata/results.sarif")
+	assert.Nil(err, "unexpected error reading test data", err)
+
+	// Test parsing SARIF results
+	results, warnings, err := ParseSARIF(data)
+	assert.Nil(err, "unexpected error while parsing SARIF results", err)
+
+	// Assert that suppressed results are mapped to skipped checks
+	assert.Equal([]*models.Check{
+		{
+			FilePath:      "main.tf",
+			FileLineRange: []int{1, 12},
+			CheckID:       "CKV_AWS_115",
+			CheckName:     "Ensure that AWS Lambda function is configured for function-level concurrent execution limit",
+			Resource:      "aws_lambda_function.example",
+			Guideline:     "https://docs.bridgecrew.io/docs/ensure-that-aws-lambda-function-is-configured-for-function-level-concurrent-execution-limit",
+			CheckResult:   &models.CheckResult{Result: "SKIPPED", SuppressComment: "hello world"},
+		},
+	}, results.Results.SkippedChecks)
+
+	// Assert that results with a rejected suppression are mapped to failed checks
+	assert.Len(results.Results.FailedChecks, 1)
+	assert.Equal("CKV_AWS_116", results.Results.FailedChecks[0].CheckID)
+	assert.Equal("FAILED", results.Results.FailedChecks[0].CheckResult.Result)
+
+	// Assert that the summary is computed from the results
+	assert.Equal(&models.Summary{Failed: 1, Skipped: 1, CheckovVersion: "2.3.296"}, results.Summary)
+
+	// Assert that results without a rule are reported
+	assert.Len(warnings, 1)
+	assert.Equal("report[0].results[2]: result has no ruleId", warnings[0].String())
+}
+
+func TestParseSARIF_Error(t *testing.T) {
+	assert := assert.New(t)
+
+	// Test parsing malformed SARIF results
+	_, _, err := ParseSARIF([]byte(`{"runs": {}}`))
+	assert.NotNil(err, "expected an error while parsing malformed SARIF results, but got no error")
+}
+
+func TestRead_Formats(t *testing.T) {
+	assert := assert.New(t)
+
+	// Prepare test data
+	sarifData, err := os.ReadFile("testdata/results.sarif")
+	assert.Nil(err, "unexpected error reading test data", err)
+	jsonData, err := os.ReadFile("testdata/multi-framework.json")
+	assert.Nil(err, "unexpected error reading test data", err)
+
+	// Test detecting input formats
+	assert.Equal(config.InputFormatSARIF, DetectFormat(sarifData))
+	assert.Equal(config.InputFormatJSON, DetectFormat(jsonData))
+	assert.Equal(config.InputFormatJSON, DetectFormat([]byte(`{"check_type": "terraform"}`)))
+
+	// Test parsing with a detected and an explicit format
+	results, _, err := Read(bytes.NewReader(sarifData), config.InputFormatAuto, ReadOptions{})
+	assert.Nil(err, "unexpected error while parsing SARIF results", err)
+	assert.Len(results.Results.SkippedChecks, 1)
+	results, _, err = Read(bytes.NewReader(jsonData), config.InputFormatJSON, ReadOptions{})
+	assert.Nil(err, "unexpected error while parsing JSON results", err)
+	assert.Len(results.Results.SkippedChecks, 3)
+
+	// Test parsing with an invalid format
+	_, _, err = Read(bytes.NewReader(jsonData), "xml", ReadOptions{})
+	assert.NotNil(err, "expected an error while parsing with an invalid format, but got no error")
+}
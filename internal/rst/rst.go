@@ -0,0 +1,172 @@
+/*
+Copyright © 2023 The checkov-docs Authors
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package rst
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/checkov-docs/checkov-docs/internal/config"
+	"github.com/checkov-docs/checkov-docs/internal/logger"
+	"github.com/checkov-docs/checkov-docs/internal/models"
+	"github.com/checkov-docs/checkov-docs/internal/table"
+)
+
+// markupEscaper escapes the characters starting inline markup and substitution references
+var markupEscaper = strings.NewReplacer(`\`, `\\`, "*", `\*`, "`", "\\`", "|", `\|`)
+
+// referencePattern matches underscores starting or ending a hyperlink reference or target
+var referencePattern = regexp.MustCompile(`(^|\W)_|_(\W|$)`)
+
+// enumeratorPattern matches the enumerator of an enumerated list at the start of a line
+var enumeratorPattern = regexp.MustCompile(`^(\(?(?:[0-9]+|[a-zA-Z]|[ivxlcdmIVXLCDM]+|#))([.)]\s)`)
+
+// blockStarts stores the characters starting a bullet list, a comment, a directive,
+// a field list, a quote or a section adornment at the start of a line
+const blockStarts = "-+•.:>=~^\"'#<([{"
+
+// urlEscaper escapes the characters that end the target of a hyperlink reference
+var urlEscaper = strings.NewReplacer(" ", "%20", "<", "%3C", ">", "%3E", "`", "%60")
+
+// WriteTable returns a reStructuredText list table with arguments headers and rows, values are escaped
+// revive:disable:unhandled-error writing to a strings.Builder doesn't fail
+func WriteTable(headers []string, rows [][]table.Cell, logger *logger.Logger) (string, error) {
+	logger.Info("create rst table")
+	var sb strings.Builder
+
+	sb.WriteString(".. list-table::\n   :header-rows: 1\n\n")
+	writeRow(&sb, headers)
+	logger.Debug("added header row")
+
+	for _, row := range rows {
+		values := make([]string, len(row))
+		for i, cell := range row {
+			values[i] = FormatCell(cell)
+		}
+		writeRow(&sb, values)
+	}
+	logger.Debug("added data rows")
+
+	return strings.TrimSuffix(sb.String(), "\n"), nil
+}
+
+// writeRow writes a row of a list table with already formatted `values`.
+// Values with several lines are written as line blocks so that line breaks are kept.
+func writeRow(sb *strings.Builder, values []string) {
+	for i, value := range values {
+		if i == 0 {
+			sb.WriteString("   * -")
+		} else {
+			sb.WriteString("     -")
+		}
+		lines := strings.Split(value, "\n")
+		for j, line := range lines {
+			switch {
+			case len(lines) > 1 && j == 0:
+				sb.WriteString(" | " + line)
+			case len(lines) > 1:
+				sb.WriteString("\n       | " + line)
+			case line != "":
+				sb.WriteString(" " + line)
+			}
+		}
+		sb.WriteString("\n")
+	}
+}
+
+// revive:enable:unhandled-error
+
+// WriteSummary returns a reStructuredText list table with a single row containing the totals in `summary`
+func WriteSummary(headers []string, summary *models.Summary, logger *logger.Logger) (string, error) {
+	logger.Info("create rst summary")
+
+	return WriteTable(headers, [][]table.Cell{table.SummaryRow(summary)}, logger)
+}
+
+// WriteGroup returns `content` below a rubric with `title`, an informal heading
+// which doesn't change the section structure of the document
+func WriteGroup(title, content string) string {
+	if title == "" {
+		title = config.GroupTitleEmpty
+	} else {
+		title = code(title)
+	}
+	return ".. rubric:: " + title + "\n\n" + content
+}
+
+// FormatCell returns the content of `cell` formatted for a list table cell, as a link if it has a URL.
+// Linked values can't be rendered as inline literals.
+func FormatCell(cell table.Cell) string {
+	switch {
+	case cell.Value == "":
+		return ""
+	case cell.URL != "":
+		text := escapeInline(strings.Join(table.SplitLines(cell.Value), " "))
+		return "`" + strings.ReplaceAll(text, "<", `\<`) + " <" + urlEscaper.Replace(cell.URL) + ">`__"
+	case cell.Code:
+		return code(cell.Value)
+	default:
+		return Escape(cell.Value)
+	}
+}
+
+// Escape returns `s` escaped to be rendered as plain text, inline markup characters
+// and underscores of hyperlink references are escaped with a backslash, as well as
+// line starts that would be parsed as a list, a comment or a directive
+func Escape(s string) string {
+	lines := table.SplitLines(escapeInline(s))
+	for i, line := range lines {
+		switch {
+		case enumeratorPattern.MatchString(line):
+			lines[i] = enumeratorPattern.ReplaceAllString(line, `$1\$2`)
+		case line != "" && strings.ContainsRune(blockStarts, firstRune(line)):
+			lines[i] = `\` + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// escapeInline returns `s` with inline markup characters and underscores of hyperlink references escaped
+func escapeInline(s string) string {
+	s = markupEscaper.Replace(s)
+	return referencePattern.ReplaceAllStringFunc(s, func(match string) string {
+		return strings.Replace(match, "_", `\_`, 1)
+	})
+}
+
+// code returns `s` as an inline literal, or as escaped text if it contains the inline literal delimiter
+// or starts or ends with a backtick. New lines are replaced with spaces.
+func code(s string) string {
+	s = strings.TrimSpace(strings.Join(table.SplitLines(s), " "))
+	if s == "" || strings.Contains(s, "``") || strings.HasPrefix(s, "`") || strings.HasSuffix(s, "`") {
+		return Escape(s)
+	}
+	return "``" + s + "``"
+}
+
+// firstRune returns the first character of `s`
+func firstRune(s string) rune {
+	r, _ := utf8.DecodeRuneInString(s)
+	return r
+}
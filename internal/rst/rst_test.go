@@ -0,0 +1,112 @@
+/*
+Copyright © 2023 The checkov-docs Authors
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package rst
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/checkov-docs/checkov-docs/internal/logger"
+	"github.com/checkov-docs/checkov-docs/internal/table"
+)
+
+func TestWriteTable(t *testing.T) {
+	assert := assert.New(t)
+	logger := logger.NewMockLogger(&bytes.Buffer{})
+
+	// Prepare test data
+	headers := []string{"Check ID", "Reason"}
+	rows := [][]table.Cell{
+		{{Value: "CKV_AWS_18", URL: "https://docs.example.com/a b"}, {Value: "logs\nare off"}},
+		{{Value: "CKV_AWS_21"}, {Value: "not *needed*", Code: true}},
+		{{Value: "CKV_AWS_19"}, {}},
+	}
+	expected := []string{
+		".. list-table::",
+		"   :header-rows: 1",
+		"",
+		"   * - Check ID",
+		"     - Reason",
+		"   * - `CKV_AWS_18 <https://docs.example.com/a%20b>`__",
+		"     - | logs",
+		"       | are off",
+		"   * - CKV_AWS_21",
+		"     - ``not *needed*``",
+		"   * - CKV_AWS_19",
+		"     -",
+	}
+
+	// Test writing table
+	output, err := WriteTable(headers, rows, logger)
+	assert.Nil(err, "unexpected error writing table", err)
+	assert.Equal(strings.Join(expected, "\n"), output)
+}
+
+func TestWriteGroup(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(".. rubric:: ``/main.tf``\n\ntable", WriteGroup("/main.tf", "table"))
+	assert.Equal(".. rubric:: (none)\n\ntable", WriteGroup("", "table"))
+}
+
+func TestEscape(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		value    string
+		expected string
+	}{
+		{"plain text", "plain text"},
+		{"snake_case", "snake_case"},
+		{"*bold* `code` |sub| a\\b", "\\*bold\\* \\`code\\` \\|sub\\| a\\\\b"},
+		{"see ref_ and _target", "see ref\\_ and \\_target"},
+		{".. END_CHECKOV_DOCS", "\\.. END_CHECKOV_DOCS"},
+		{"- item\n1. first\n(a) second", "\\- item\n1\\. first\n(a\\) second"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(tt.expected, Escape(tt.value), "unexpected escaped value for %q", tt.value)
+	}
+}
+
+func TestFormatCell(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		cell     table.Cell
+		expected string
+	}{
+		{table.Cell{}, ""},
+		{table.Cell{Value: "a\nb", Code: true}, "``a b``"},
+		{table.Cell{Value: "a ``b``", Code: true}, "a \\`\\`b\\`\\`"},
+		{table.Cell{Value: "`a`", Code: true}, "\\`a\\`"},
+		{table.Cell{Value: "<x>", URL: "https://example.com/`<>"}, "`\\<x> <https://example.com/%60%3C%3E>`__"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(tt.expected, FormatCell(tt.cell), "unexpected formatted cell for %+v", tt.cell)
+	}
+}
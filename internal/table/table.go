@@ -0,0 +1,158 @@
+/*
+Copyright © 2023 The checkov-docs Authors
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package table
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/checkov-docs/checkov-docs/internal/config"
+	"github.com/checkov-docs/checkov-docs/internal/links"
+	"github.com/checkov-docs/checkov-docs/internal/models"
+)
+
+// Table stores the header and data rows generated from checks.
+// Both are derived from the same columns so that they always have the same width.
+type Table struct {
+	// Headers stores the title of each column
+	Headers []string
+	// Rows stores the cell of each column for each check
+	Rows [][]Cell
+}
+
+// Cell stores the value of a column for a check
+type Cell struct {
+	// Value is the string representation of the check field
+	Value string
+	// Code renders the value as inline code
+	Code bool
+	// URL is the address the value links to, the value isn't linked if empty
+	URL string
+}
+
+// String returns the value of the cell
+func (c Cell) String() string {
+	return c.Value
+}
+
+// titles stores the default title of check fields, other fields are titled from their name
+var titles = map[string]string{
+	"file_path":        "File",
+	"file_line_range":  "Line",
+	"check_id":         "Check ID",
+	"bc_check_id":      "BC Check ID",
+	"check_type":       "Framework",
+	"resource":         "Resource ID",
+	"suppress_comment": "Reason",
+}
+
+// New returns a table with a row for each of `checks` and the fields selected by `columns`.
+// Aliases of field names are resolved, an error is returned if a field is not valid.
+// Cells are linked by `linker`, nothing is linked if nil.
+func New(columns []config.Column, checks []*models.Check, linker *links.Linker) (*Table, error) {
+	fields, err := Fields(columns)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &Table{
+		Headers: make([]string, len(columns)),
+		Rows:    make([][]Cell, len(checks)),
+	}
+	for i, column := range columns {
+		t.Headers[i] = column.Title
+		if t.Headers[i] == "" {
+			t.Headers[i] = Title(fields[i])
+		}
+	}
+	for i, check := range checks {
+		row := make([]Cell, len(fields))
+		for j, field := range fields {
+			url, err := linker.URL(field, check)
+			if err != nil {
+				return nil, err
+			}
+			row[j] = Cell{Value: check.Field(field), Code: columns[j].Code, URL: url}
+		}
+		t.Rows[i] = row
+	}
+
+	return t, nil
+}
+
+// Fields returns the check field rendered in each of `columns`
+func Fields(columns []config.Column) ([]string, error) {
+	if len(columns) == 0 {
+		return nil, errors.New("no columns to render")
+	}
+
+	fields := make([]string, len(columns))
+	for i, column := range columns {
+		fields[i] = models.FieldName(column.Field)
+		if fields[i] == "" {
+			return nil, fmt.Errorf("invalid column field %q, valid values: %v", column.Field, models.Fields)
+		}
+	}
+
+	return fields, nil
+}
+
+// Title returns the default title of the check field `field`, e.g. `Check Name` for `check_name`
+func Title(field string) string {
+	if title, ok := titles[field]; ok {
+		return title
+	}
+
+	words := strings.Split(field, "_")
+	for i, word := range words {
+		if word == "id" {
+			words[i] = "ID"
+			continue
+		}
+		words[i] = strings.ToUpper(word[:1]) + word[1:]
+	}
+
+	return strings.Join(words, " ")
+}
+
+// SummaryRow returns the cells of the totals in `summary`, in the order of config.SummaryHeader
+func SummaryRow(summary *models.Summary) []Cell {
+	return []Cell{
+		{Value: strconv.Itoa(summary.Passed)},
+		{Value: strconv.Itoa(summary.Failed)},
+		{Value: strconv.Itoa(summary.Skipped)},
+		{Value: strconv.Itoa(summary.ParsingErrors)},
+		{Value: strconv.Itoa(summary.ResourceCount)},
+		{Value: summary.CheckovVersion},
+	}
+}
+
+// SplitLines returns the lines of `s`, which can end with `\n`, `\r\n` or `\r`
+func SplitLines(s string) []string {
+	return strings.Split(lineEndings.Replace(s), "\n")
+}
+
+// lineEndings replaces line endings with `\n`
+var lineEndings = strings.NewReplacer("\r\n", "\n", "\r", "\n")
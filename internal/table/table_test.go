@@ -0,0 +1,89 @@
+/*
+Copyright © 2023 The checkov-docs Authors
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package table
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/checkov-docs/checkov-docs/internal/config"
+	"github.com/checkov-docs/checkov-docs/internal/models"
+)
+
+func TestNew(t *testing.T) {
+	assert := assert.New(t)
+
+	// Prepare test data
+	checks := []*models.Check{
+		{FilePath: "/main.tf", FileLineRange: []int{1, 8}, CheckID: "CKV_AWS_18", Severity: "LOW"},
+		{FilePath: "/s3.tf", CheckID: "CKV_AWS_21", CheckResult: &models.CheckResult{SuppressComment: "versioning is not needed"}},
+	}
+	columns := []config.Column{
+		{Field: "check_id"},
+		{Field: "severity", Title: "Sev"},
+		{Field: "line"},
+		{Field: "reason", Code: true},
+	}
+
+	// Test creating header and rows from the same columns
+	table, err := New(columns, checks, nil)
+	assert.Nil(err, "unexpected error creating table", err)
+	assert.Equal([]string{"Check ID", "Sev", "Line", "Reason"}, table.Headers)
+	assert.Equal([][]Cell{
+		{{Value: "CKV_AWS_18"}, {Value: "LOW"}, {Value: "1-8"}, {Code: true}},
+		{{Value: "CKV_AWS_21"}, {}, {}, {Value: "versioning is not needed", Code: true}},
+	}, table.Rows)
+
+	// Test creating a table with an invalid field
+	_, err = New([]config.Column{{Field: "unknown"}}, checks, nil)
+	assert.NotNil(err, "expected an error for an invalid field, but got no error")
+
+	// Test creating a table without columns
+	_, err = New(nil, checks, nil)
+	assert.NotNil(err, "expected an error for missing columns, but got no error")
+}
+
+func TestTitle(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("File", Title("file_path"))
+	assert.Equal("Resource ID", Title("resource"))
+	assert.Equal("Check Name", Title("check_name"))
+	assert.Equal("Resource Type", Title("resource_type"))
+	assert.Equal("Start Line", Title("start_line"))
+}
+
+func TestSummaryRow(t *testing.T) {
+	assert := assert.New(t)
+
+	summary := &models.Summary{Passed: 3, Failed: 2, Skipped: 1, ResourceCount: 4, CheckovVersion: "2.3.296"}
+	assert.Equal([]Cell{{Value: "3"}, {Value: "2"}, {Value: "1"}, {Value: "0"}, {Value: "4"}, {Value: "2.3.296"}}, SummaryRow(summary))
+}
+
+func TestSplitLines(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal([]string{"a", "b", "c", "d"}, SplitLines("a\nb\r\nc\rd"))
+	assert.Equal([]string{""}, SplitLines(""))
+}
@@ -0,0 +1,153 @@
+/*
+Copyright © 2023 The checkov-docs Authors
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package template
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"text/template"
+
+	"github.com/checkov-docs/checkov-docs/internal/findings"
+	"github.com/checkov-docs/checkov-docs/internal/markdown"
+	"github.com/checkov-docs/checkov-docs/internal/models"
+	"github.com/checkov-docs/checkov-docs/internal/table"
+)
+
+// Data stores the values available in user-defined templates
+type Data struct {
+	// Checks are the selected checks, in the order of the table rows
+	Checks []*models.Check
+	// Table stores the header and data rows of the selected checks
+	Table *table.Table
+	// Groups are the checks grouped by the group-by field, nil if checks aren't grouped
+	Groups []*findings.Group
+	// Summary is the merged summary of checkov results, nil if no input has one
+	Summary *models.Summary
+	// Content is the generated markdown, i.e. the table and the summary if enabled
+	Content string
+	// ResultType is the documented result type, e.g. `skipped`
+	ResultType string
+	// CheckType is the framework of checkov results, empty if inputs have different frameworks
+	CheckType string
+	// Inputs are the names of the inputs checkov results were read from
+	Inputs []string
+}
+
+// Funcs stores the helper functions available in user-defined templates
+var Funcs = template.FuncMap{
+	"join":           join,
+	"upper":          strings.ToUpper,
+	"lower":          strings.ToLower,
+	"default":        defaultValue,
+	"replace":        replace,
+	"truncate":       truncate,
+	"markdownEscape": markdown.Escape,
+	"link":           link,
+}
+
+// Load returns the template parsed from the file at `path`
+func Load(path string) (*template.Template, error) {
+	text, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template file: %w", err)
+	}
+	return Parse(filepath.Base(path), string(text))
+}
+
+// Parse returns the template `name` parsed from `text` with the helper functions
+func Parse(name, text string) (*template.Template, error) {
+	tpl, err := template.New(name).Funcs(Funcs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+	return tpl, nil
+}
+
+// Render returns the output of `tpl` applied to `data`.
+// Leading and trailing new lines are removed so that the output is placed like the default table.
+func Render(tpl *template.Template, data *Data) (string, error) {
+	var buf bytes.Buffer
+	if err := tpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to execute template: %w", err)
+	}
+	return strings.Trim(buf.String(), "\n"), nil
+}
+
+// join returns the elements of `values` separated by `sep`, e.g. `{{ .Details | join ", " }}`
+func join(sep string, values interface{}) (string, error) {
+	v := reflect.ValueOf(values)
+	if !v.IsValid() {
+		return "", nil
+	}
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return "", fmt.Errorf("join: expected a list, got %T", values)
+	}
+
+	elems := make([]string, v.Len())
+	for i := range elems {
+		elems[i] = fmt.Sprint(v.Index(i).Interface())
+	}
+	return strings.Join(elems, sep), nil
+}
+
+// defaultValue returns `def` if `value` is empty, e.g. `{{ .Severity | default "UNKNOWN" }}`
+func defaultValue(def, value interface{}) interface{} {
+	v := reflect.ValueOf(value)
+	if !v.IsValid() || v.IsZero() {
+		return def
+	}
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		if v.Len() == 0 {
+			return def
+		}
+	}
+	return value
+}
+
+// replace returns `s` with all occurrences of `old` replaced by `new`, e.g. `{{ .CheckID | replace "_" "-" }}`
+func replace(old, new, s string) string {
+	return strings.ReplaceAll(s, old, new)
+}
+
+// truncate returns the first `n` characters of `s`, ending with an ellipsis if `s` is longer
+func truncate(n int, s string) string {
+	runes := []rune(s)
+	if n < 0 || len(runes) <= n {
+		return s
+	}
+	if n == 0 {
+		return ""
+	}
+	return string(runes[:n-1]) + "…"
+}
+
+// link returns a markdown link to `url` with `text`, or `text` alone if `url` is empty.
+// Like table cells, `text` is escaped so that it can't end the link or inject markup, and `url` is percent-encoded.
+func link(url, text string) string {
+	return markdown.FormatCell(table.Cell{Value: text, URL: url})
+}
@@ -0,0 +1,117 @@
+/*
+Copyright © 2023 The checkov-docs Authors
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package template
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/checkov-docs/checkov-docs/internal/models"
+	"github.com/checkov-docs/checkov-docs/internal/table"
+)
+
+func TestRender(t *testing.T) {
+	assert := assert.New(t)
+
+	// Prepare test data
+	data := &Data{
+		Checks: []*models.Check{
+			{CheckID: "CKV_AWS_18", CheckName: "Ensure the S3 bucket has access logging enabled", Guideline: "https://docs.example.com/ckv-aws-18", Severity: "LOW"},
+			{CheckID: "CKV_AWS_21", CheckName: "Ensure all data stored in the S3 bucket have versioning enabled"},
+		},
+		Table:      &table.Table{Headers: []string{"Check ID"}, Rows: [][]table.Cell{{{Value: "CKV_AWS_18"}}, {{Value: "CKV_AWS_21"}}}},
+		Summary:    &models.Summary{Failed: 2, CheckovVersion: "2.3.296"},
+		ResultType: "failed",
+		CheckType:  "terraform",
+		Inputs:     []string{"a.json", "b.json"},
+	}
+	text := `
+## {{ .CheckType | upper }} ({{ .Summary.Failed }} {{ .ResultType }})
+{{ range .Checks }}
+- {{ .CheckID | link .Guideline }} {{ .CheckName | truncate 20 }} [{{ .Severity | default "NONE" }}]
+{{- end }}
+
+{{ .Table.Headers | join ", " }} from {{ .Inputs | join ", " }}
+`
+	expected := `## TERRAFORM (2 failed)
+
+- [CKV_AWS_18](https://docs.example.com/ckv-aws-18) Ensure the S3 bucke… [LOW]
+- CKV_AWS_21 Ensure all data sto… [NONE]
+
+Check ID from a.json, b.json`
+
+	// Test rendering template
+	tpl, err := Parse("test", text)
+	assert.Nil(err, "unexpected error parsing template", err)
+	output, err := Render(tpl, data)
+	assert.Nil(err, "unexpected error rendering template", err)
+	assert.Equal(expected, output)
+
+	// Test parsing an invalid template
+	_, err = Parse("test", "{{ .Checks ")
+	assert.NotNil(err, "expected an error for an invalid template, but got no error")
+
+	// Test rendering a template with an unknown field
+	tpl, err = Parse("test", "{{ .Unknown }}")
+	assert.Nil(err, "unexpected error parsing template", err)
+	_, err = Render(tpl, data)
+	assert.NotNil(err, "expected an error for an unknown field, but got no error")
+}
+
+func TestFuncs(t *testing.T) {
+	assert := assert.New(t)
+
+	// join
+	joined, err := join(", ", []string{"a", "b"})
+	assert.Nil(err, "unexpected error joining values", err)
+	assert.Equal("a, b", joined)
+	joined, err = join("-", []interface{}{1, "x"})
+	assert.Nil(err, "unexpected error joining values", err)
+	assert.Equal("1-x", joined)
+	joined, err = join(", ", nil)
+	assert.Nil(err, "unexpected error joining values", err)
+	assert.Equal("", joined)
+	_, err = join(", ", "a")
+	assert.NotNil(err, "expected an error joining a string, but got no error")
+
+	// default
+	assert.Equal("none", defaultValue("none", ""))
+	assert.Equal("none", defaultValue("none", nil))
+	assert.Equal("none", defaultValue("none", []string{}))
+	assert.Equal("HIGH", defaultValue("none", "HIGH"))
+
+	// replace
+	assert.Equal("CKV-AWS-18", replace("_", "-", "CKV_AWS_18"))
+
+	// truncate
+	assert.Equal("héll…", truncate(5, "héllo world"))
+	assert.Equal("hello", truncate(5, "hello"))
+	assert.Equal("", truncate(0, "hello"))
+
+	// link
+	assert.Equal("[CKV_AWS_18](https://example.com)", link("https://example.com", "CKV_AWS_18"))
+	assert.Equal("CKV_AWS_18", link("", "CKV_AWS_18"))
+	assert.Equal(`[a\]b \[x\](y) &lt;b&gt;](https://example.com/a%20b%29%3C)`, link("https://example.com/a b)<", "a]b [x](y) <b>"))
+	assert.Equal("a\\|b&lt;br&gt;", link("", "a|b<br>"))
+}
@@ -22,13 +22,18 @@ THE SOFTWARE.
 package main
 
 import (
+	"errors"
 	"os"
 
 	"github.com/checkov-docs/checkov-docs/cmd"
+	"github.com/checkov-docs/checkov-docs/internal/cli"
 )
 
 func main() {
 	if err := cmd.Execute(); err != nil {
+		if errors.Is(err, cli.ErrOutdated) {
+			os.Exit(cli.ExitCodeOutdated)
+		}
 		os.Exit(1)
 	}
 }